@@ -0,0 +1,129 @@
+package emodl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Size of badges at 1x in chat.
+const badgeSize = 18
+
+// Badge is a third party chat badge shown next to the names of the users
+// it is assigned to.
+type Badge struct {
+	ID       string `json:"id"`
+	Provider string `json:"provider"`
+	Name     string `json:"name"`
+
+	// Tooltip shown when hovering the badge
+	Title string `json:"title"`
+
+	// CSS background color of the badge, if any
+	Color string `json:"color"`
+
+	Images []Image `json:"images"`
+
+	// Platform user IDs the badge is assigned to
+	Users []string `json:"users"`
+}
+
+// LoadBadges loads the badges of every provider implementing BadgeProvider.
+//
+// Downloading is bounded by a timeout of downloadTimeout seconds.
+func (ed *Downloader) LoadBadges() error {
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout*time.Second)
+	defer cancel()
+	return ed.LoadBadgesContext(ctx)
+}
+
+// LoadBadgesContext is like LoadBadges but cancellation and deadlines of ctx
+// apply to every request in flight.
+//
+// If a request fails, the badges of its last successful load are kept.
+func (ed *Downloader) LoadBadgesContext(ctx context.Context) error {
+	var bps []Provider
+	for _, p := range ed.providers {
+		if _, ok := p.(BadgeProvider); ok {
+			bps = append(bps, p)
+		}
+	}
+	results, err := fetchAll(ctx, bps, "badges", func(p Provider) ([]Badge, error) {
+		return p.(BadgeProvider).Badges(ctx)
+	})
+
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	for _, r := range results {
+		if r.err != nil {
+			err = errors.Join(err, r.err)
+			continue
+		}
+		ed.badgeSources[r.provider] = r.values
+	}
+
+	clear(ed.badges)
+	for _, p := range ed.providers {
+		for _, b := range ed.badgeSources[p.Name()] {
+			for _, u := range b.Users {
+				ed.badges[u] = append(ed.badges[u], b)
+			}
+		}
+	}
+	return err
+}
+
+// Badges returns the badges assigned to a platform user ID in provider
+// order.
+func (ed *Downloader) Badges(userID string) []Badge {
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+	return ed.badges[userID]
+}
+
+// Returns an image of a badge at a scale of 1, 2, 3 or 4. Its ID is unique
+// per scale.
+func badgeImage(id string, url string, scale int) Image {
+	return Image{
+		URL:    url,
+		Width:  scale * badgeSize,
+		Height: scale * badgeSize,
+		ID:     id + "/" + strconv.Itoa(scale),
+	}
+}
+
+// The values fetched from a single provider.
+type fetchResult[T any] struct {
+	provider string
+	values   []T
+	err      error
+}
+
+// Fetches from every provider concurrently. Returns the results received
+// before ctx is done along with the context error.
+func fetchAll[T any](ctx context.Context, providers []Provider, kind string, fetch func(p Provider) ([]T, error)) ([]fetchResult[T], error) {
+	resultChan := make(chan fetchResult[T], len(providers))
+	for _, p := range providers {
+		go func() {
+			vs, err := fetch(p)
+			if err != nil {
+				err = fmt.Errorf("emodl: %v: failure getting %s %s", err, p.Name(), kind)
+			}
+			resultChan <- fetchResult[T]{provider: p.Name(), values: vs, err: err}
+		}()
+	}
+
+	results := make([]fetchResult[T], 0, len(providers))
+	for range providers {
+		select {
+		case r := <-resultChan:
+			results = append(results, r)
+		case <-ctx.Done():
+			return results, ctx.Err()
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,93 @@
+package emodl
+
+import (
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDownloaderBadges(t *testing.T) {
+	t.Parallel()
+	srv := newFakeServer(t)
+	ed := NewDownloader(fakeChannelOptions(srv))
+	if err := ed.LoadBadges(); err != nil {
+		t.Fatal(err)
+	}
+
+	badges := ed.Badges(fakeTwitchID)
+	if len(badges) != 3 {
+		t.Fatalf("Expected 3 badges, got %+v", badges)
+	}
+	for i, want := range []struct{ provider, title string }{
+		{ProviderBTTV, "BTTV Developer"},
+		{ProviderSevenTV, "7TV Subscriber (1 Year)"},
+		{ProviderFFZ, "FrankerFaceZ Developer"},
+	} {
+		if badges[i].Provider != want.provider || badges[i].Title != want.title {
+			t.Errorf("Badge %d: expected %s %q, got %s %q", i, want.provider, want.title, badges[i].Provider, badges[i].Title)
+		}
+	}
+
+	// BTTV users with the same badge share it
+	if bs := ed.Badges("19264788"); len(bs) != 2 || bs[0].Provider != ProviderBTTV || len(bs[0].Users) != 2 {
+		t.Errorf("Expected shared BTTV and 7TV badges, got %+v", bs)
+	}
+
+	ffz := badges[2]
+	if ffz.Color != "#FAAF19" || len(ffz.Images) != 3 || ffz.Images[2].Width != 72 || ffz.Images[2].URL != "https://cdn.frankerfacez.com/badge/1/4" {
+		t.Errorf("Unexpected FFZ badge %+v", ffz)
+	}
+	sevenTV := badges[1]
+	if len(sevenTV.Images) != 3 || sevenTV.Images[0].Width != 18 || sevenTV.Images[0].ID != "62f97c05e46eb00e438a6969/1" {
+		t.Errorf("Unexpected 7TV badge %+v", sevenTV)
+	}
+
+	if bs := ed.Badges("1"); len(bs) != 0 {
+		t.Errorf("Expected no badges, got %+v", bs)
+	}
+}
+
+func TestBadgeImagesProbe(t *testing.T) {
+	t.Parallel()
+	srv := newFakeServer(t)
+	ed := NewDownloader(fakeChannelOptions(srv))
+	if err := ed.LoadBadges(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewImageFetcher(&http.Client{Transport: fakeBadgeTransport{}}, "", 2)
+
+	// Probing a scale must not return the cached size of another
+	ffz := ed.Badges(fakeTwitchID)[2]
+	for _, img := range ffz.Images {
+		img, err := f.Probe(context.Background(), img)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scale := img.Width / 20
+		if img.URL != "https://cdn.frankerfacez.com/badge/1/"+strconv.Itoa(scale) {
+			t.Errorf("%s: got the %dx%d image of another scale", img.URL, img.Width, img.Height)
+		}
+	}
+}
+
+// Serves FFZ badge images 20 pixels wide per scale, the last part of their
+// URLs.
+type fakeBadgeTransport struct{}
+
+func (fakeBadgeTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	scale, err := strconv.Atoi(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+	if err != nil {
+		return nil, err
+	}
+	w := httptest.NewRecorder()
+	if err := png.Encode(w, image.NewRGBA(image.Rect(0, 0, 20*scale, 20*scale))); err != nil {
+		return nil, err
+	}
+	return w.Result(), nil
+}
@@ -4,21 +4,18 @@ package emodl
 // https://betterttv.com/developers/api
 
 import (
+	"context"
 	"errors"
-	"io"
-	"net/http"
-	"net/url"
+	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 	"text/template"
 	"unsafe"
-
-	"github.com/mailru/easyjson"
 )
 
 var (
 	bttvAPIVersion      = "3"
-	bttvHost            = "api.betterttv.net"
 	bttvCDNPathTmpl, _  = template.New("bttvCDN").Parse("https://cdn.betterttv.net/emote/{{ .ID }}/1x.webp")
 	bttvUserPathTmpl, _ = template.New("bttvUserPath").Parse("/{{ .Version }}/cached/users/{{ .Platform }}/{{ .PlatformID }}")
 )
@@ -61,7 +58,18 @@ type BTTVEmote struct {
 	// The image type is a lie. We always can get webp.
 	//ImageType string `json:"imageType,intern"`
 	Animated bool `json:"animated"`
-	//UserID   string `json:"userID,intern"`
+	// Zero-width emotes such as cvHazmat
+	Modifier bool   `json:"modifier"`
+	UserID   string `json:"userId,intern"`
+	// Uploader of shared emotes
+	User *BTTVEmoteUser `json:"user"`
+}
+
+type BTTVEmoteUser struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	ProviderID  string `json:"providerId"`
 }
 
 func (e BTTVEmote) URL() string {
@@ -84,13 +92,63 @@ func (e BTTVEmote) Image() Image {
 	}
 }
 
+// Scales of BTTV emote images.
+var bttvScales = []int{1, 2, 3}
+
+// AllImages returns an image of every scale of the emote, as WEBP and in the
+// format it was uploaded in, which is GIF for animated emotes and PNG
+// otherwise.
+func (e BTTVEmote) AllImages() []Image {
+	original := "PNG"
+	if e.Animated {
+		original = "GIF"
+	}
+	imgs := make([]Image, 0, 2*len(bttvScales))
+	for _, n := range bttvScales {
+		scale := strconv.Itoa(n) + "x"
+		for _, f := range []struct{ format, ext string }{{"WEBP", ".webp"}, {original, ""}} {
+			imgs = append(imgs, Image{
+				URL:    "https://cdn.betterttv.net/emote/" + e.ID + "/" + scale + f.ext,
+				Width:  n * 28,
+				Height: n * 28,
+				ID:     e.ID + "/" + scale + f.ext,
+				Scale:  scale,
+				Format: f.format,
+			})
+		}
+	}
+	return imgs
+}
+
+// GetImage returns the image of the emote nearest to a scale ("1x", "2x" or
+// "3x") and format, WEBP if the format is missing.
+func (e BTTVEmote) GetImage(scale string, format string) (Image, error) {
+	if e.ID == "" {
+		return Image{}, errors.New("BTTV Emote has no id")
+	}
+	img, _ := bestImage(e.AllImages(), scale, []string{format, "WEBP"})
+	return img, nil
+}
+
+// Shared emotes are the emotes listed with an uploader. Other emotes only
+// have the BTTV ID of their owner.
 func (e BTTVEmote) AsEmote() Emote {
-	return Emote{
+	em := Emote{
 		ID:        e.ID,
 		Name:      e.Name,
-		Images:    []Image{e.Image()},
+		Provider:  ProviderBTTV,
+		Animated:  e.Animated,
+		ZeroWidth: e.Modifier,
+		Images:    e.AllImages(),
 		Locations: []string{},
 	}
+	if e.User != nil {
+		em.Scope = ScopeShared
+		em.Owner = &EmoteOwner{ID: e.User.ID, Name: e.User.Name, DisplayName: e.User.DisplayName}
+	} else if e.UserID != "" {
+		em.Owner = &EmoteOwner{ID: e.UserID}
+	}
+	return em
 }
 
 func (e BTTVEmote) Size() uintptr {
@@ -104,7 +162,7 @@ type BTTVUser struct {
 	SharedEmotes  []BTTVEmote `json:"sharedEmotes"`
 }
 
-func getBTTVUser(platform string, platformID string) (BTTVUser, error) {
+func getBTTVUser(ctx context.Context, c *apiClient, platform string, platformID string) (BTTVUser, error) {
 	var u BTTVUser
 	sb := strings.Builder{}
 	err := bttvUserPathTmpl.Execute(&sb, bttvUserPath{
@@ -116,52 +174,23 @@ func getBTTVUser(platform string, platformID string) (BTTVUser, error) {
 		panic(err)
 	}
 
-	req := &http.Request{
-		Method: "GET",
-		URL: &url.URL{
-			Scheme: "https",
-			Host:   bttvHost,
-			Path:   sb.String(),
-		},
-		Header: http.Header{},
-	}
-
-	response, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return u, err
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		errorMessage := &jsonError{}
-		err = easyjson.UnmarshalFromReader(response.Body, errorMessage)
-		if err != nil {
-			body, err := io.ReadAll(response.Body)
-			if err != nil {
-				return u, err
-			}
-			return u, errors.New(response.Status + "\n" + string(body))
-		}
-		return u, errors.New(errorMessage.Error.Message)
-	}
-
-	err = easyjson.UnmarshalFromReader(response.Body, &u)
+	err = c.getJSON(ctx, sb.String(), &u)
 	if err != nil {
 		return u, err
 	}
 	return u, nil
 }
 
-func getBTTVUserEmotes(platform string, platformID string) (BTTVEmoteSlice, error) {
+func getBTTVUserEmotes(ctx context.Context, c *apiClient, platform string, platformID string) (BTTVEmoteSlice, error) {
 	bttvEmotes := BTTVEmoteSlice{}
-	u, err := getBTTVUser(platform, platformID)
+	u, err := getBTTVUser(ctx, c, platform, platformID)
 	if err != nil {
 		return bttvEmotes, err
 	}
 	return slices.Concat(u.SharedEmotes, u.ChannelEmotes), nil
 }
 
-func getBTTVGlobalEmotes() (BTTVEmoteSlice, error) {
+func getBTTVGlobalEmotes(ctx context.Context, c *apiClient) (BTTVEmoteSlice, error) {
 	var bttvEmotes BTTVEmoteSlice
 	sb := strings.Builder{}
 	err := apiPathTmpl.Execute(&sb, apiPath{
@@ -172,39 +201,129 @@ func getBTTVGlobalEmotes() (BTTVEmoteSlice, error) {
 		return bttvEmotes, err
 	}
 
-	req := &http.Request{
-		Method: "GET",
-		URL: &url.URL{
-			Scheme: "https",
-			Host:   bttvHost,
-			Path:   sb.String(),
-		},
-		Header: http.Header{},
+	err = c.getJSON(ctx, sb.String(), &bttvEmotes)
+	if err != nil {
+		return bttvEmotes, err
+	}
+
+	return bttvEmotes, nil
+}
+
+// A user with a BTTV badge.
+type BTTVBadgeUser struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	ProviderID string `json:"providerId"`
+	Badge      struct {
+		Type        int    `json:"type"`
+		Description string `json:"description"`
+		SVG         string `json:"svg"`
+	} `json:"badge"`
+}
+
+//easyjson:json
+type BTTVBadgeUserSlice []BTTVBadgeUser
+
+func getBTTVBadges(ctx context.Context, c *apiClient, platform string) (BTTVBadgeUserSlice, error) {
+	var users BTTVBadgeUserSlice
+	sb := strings.Builder{}
+	err := apiPathOptionTmpl.Execute(&sb, apiPath{
+		Version: bttvAPIVersion,
+		Path:    "cached/badges",
+		Option:  platform,
+	})
+	if err != nil {
+		return users, err
+	}
+
+	err = c.getJSON(ctx, sb.String(), &users)
+	if err != nil {
+		return users, err
 	}
+	return users, nil
+}
+
+type bttvProvider struct {
+	opt    *BTTVOptions
+	client *apiClient
+}
 
-	response, err := http.DefaultClient.Do(req)
+func newBTTVProvider(opt DownloaderOptions) Provider {
+	return &bttvProvider{
+		opt:    opt.BTTV,
+		client: newAPIClient(opt, ProviderBTTV, bttvBaseURL),
+	}
+}
+
+func (p *bttvProvider) Name() string {
+	return ProviderBTTV
+}
+
+func (p *bttvProvider) GlobalEmotes(ctx context.Context) ([]ProviderEmote, error) {
+	es, err := getBTTVGlobalEmotes(ctx, p.client)
 	if err != nil {
-		return bttvEmotes, err
+		return nil, err
 	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		errorMessage := &jsonError{}
-		err = easyjson.UnmarshalFromReader(response.Body, errorMessage)
-		if err != nil {
-			body, err := io.ReadAll(response.Body)
-			if err != nil {
-				return bttvEmotes, err
-			}
-			return bttvEmotes, errors.New(response.Status + "\n" + string(body))
-		}
-		return bttvEmotes, errors.New(errorMessage.Error.Message)
+	return bttvProviderEmotes(es), nil
+}
+
+func (p *bttvProvider) ChannelEmotes(ctx context.Context) ([]ProviderEmote, error) {
+	if p.opt == nil {
+		return nil, nil
+	}
+	es, err := getBTTVUserEmotes(ctx, p.client, p.opt.Platform, p.opt.PlatformID)
+	if err != nil {
+		return nil, err
+	}
+	return bttvProviderEmotes(es), nil
+}
+
+func (p *bttvProvider) AsEmote(e ProviderEmote) (Emote, error) {
+	be, ok := e.(BTTVEmote)
+	if !ok {
+		return Emote{}, fmt.Errorf("expected BTTVEmote, got %T", e)
 	}
+	return be.AsEmote(), nil
+}
 
-	err = easyjson.UnmarshalFromReader(response.Body, &bttvEmotes)
+// BTTV lists badges by user, so users with the same badge type are grouped.
+// Badges are on the platform of the configured channel (Twitch if none).
+func (p *bttvProvider) Badges(ctx context.Context) ([]Badge, error) {
+	platform := "twitch"
+	if p.opt != nil && p.opt.Platform != "" {
+		platform = strings.ToLower(p.opt.Platform)
+	}
+	users, err := getBTTVBadges(ctx, p.client, platform)
 	if err != nil {
-		return bttvEmotes, err
+		return nil, err
 	}
 
-	return bttvEmotes, nil
+	var badges []Badge
+	index := make(map[int]int, 8)
+	for _, u := range users {
+		i, ok := index[u.Badge.Type]
+		if !ok {
+			// BTTV badges are SVG, shown at the size of other badges
+			id := strconv.Itoa(u.Badge.Type)
+			i = len(badges)
+			index[u.Badge.Type] = i
+			badges = append(badges, Badge{
+				ID:       id,
+				Provider: ProviderBTTV,
+				Name:     u.Badge.Description,
+				Title:    u.Badge.Description,
+				Images:   []Image{badgeImage(id, u.Badge.SVG, 1)},
+			})
+		}
+		badges[i].Users = append(badges[i].Users, u.ProviderID)
+	}
+	return badges, nil
+}
+
+func bttvProviderEmotes(es BTTVEmoteSlice) []ProviderEmote {
+	pes := make([]ProviderEmote, 0, len(es))
+	for _, e := range es {
+		pes = append(pes, e)
+	}
+	return pes
 }
@@ -186,6 +186,20 @@ func easyjson515ca9ccDecodeGithubComJdavasligilEmodl1(in *jlexer.Lexer, out *BTT
 			out.Name = string(in.String())
 		case "animated":
 			out.Animated = bool(in.Bool())
+		case "modifier":
+			out.Modifier = bool(in.Bool())
+		case "userId":
+			out.UserID = string(in.StringIntern())
+		case "user":
+			if in.IsNull() {
+				in.Skip()
+				out.User = nil
+			} else {
+				if out.User == nil {
+					out.User = new(BTTVEmoteUser)
+				}
+				easyjson515ca9ccDecodeGithubComJdavasligilEmodl2(in, out.User)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -215,9 +229,91 @@ func easyjson515ca9ccEncodeGithubComJdavasligilEmodl1(out *jwriter.Writer, in BT
 		out.RawString(prefix)
 		out.Bool(bool(in.Animated))
 	}
+	{
+		const prefix string = ",\"modifier\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Modifier))
+	}
+	{
+		const prefix string = ",\"userId\":"
+		out.RawString(prefix)
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		if in.User == nil {
+			out.RawString("null")
+		} else {
+			easyjson515ca9ccEncodeGithubComJdavasligilEmodl2(out, *in.User)
+		}
+	}
 	out.RawByte('}')
 }
-func easyjson515ca9ccDecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *BTTVEmoteSlice) {
+func easyjson515ca9ccDecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *BTTVEmoteUser) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "displayName":
+			out.DisplayName = string(in.String())
+		case "providerId":
+			out.ProviderID = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson515ca9ccEncodeGithubComJdavasligilEmodl2(out *jwriter.Writer, in BTTVEmoteUser) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"displayName\":"
+		out.RawString(prefix)
+		out.String(string(in.DisplayName))
+	}
+	{
+		const prefix string = ",\"providerId\":"
+		out.RawString(prefix)
+		out.String(string(in.ProviderID))
+	}
+	out.RawByte('}')
+}
+func easyjson515ca9ccDecodeGithubComJdavasligilEmodl3(in *jlexer.Lexer, out *BTTVEmoteSlice) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -245,7 +341,7 @@ func easyjson515ca9ccDecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *BTT
 		in.Consumed()
 	}
 }
-func easyjson515ca9ccEncodeGithubComJdavasligilEmodl2(out *jwriter.Writer, in BTTVEmoteSlice) {
+func easyjson515ca9ccEncodeGithubComJdavasligilEmodl3(out *jwriter.Writer, in BTTVEmoteSlice) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -263,23 +359,216 @@ func easyjson515ca9ccEncodeGithubComJdavasligilEmodl2(out *jwriter.Writer, in BT
 // MarshalJSON supports json.Marshaler interface
 func (v BTTVEmoteSlice) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson515ca9ccEncodeGithubComJdavasligilEmodl2(&w, v)
+	easyjson515ca9ccEncodeGithubComJdavasligilEmodl3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v BTTVEmoteSlice) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson515ca9ccEncodeGithubComJdavasligilEmodl2(w, v)
+	easyjson515ca9ccEncodeGithubComJdavasligilEmodl3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *BTTVEmoteSlice) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson515ca9ccDecodeGithubComJdavasligilEmodl2(&r, v)
+	easyjson515ca9ccDecodeGithubComJdavasligilEmodl3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *BTTVEmoteSlice) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson515ca9ccDecodeGithubComJdavasligilEmodl2(l, v)
+	easyjson515ca9ccDecodeGithubComJdavasligilEmodl3(l, v)
+}
+func easyjson515ca9ccDecodeGithubComJdavasligilEmodl4(in *jlexer.Lexer, out *BTTVBadgeUserSlice) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(BTTVBadgeUserSlice, 0, 0)
+			} else {
+				*out = BTTVBadgeUserSlice{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v10 BTTVBadgeUser
+			easyjson515ca9ccDecodeGithubComJdavasligilEmodl5(in, &v10)
+			*out = append(*out, v10)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson515ca9ccEncodeGithubComJdavasligilEmodl4(out *jwriter.Writer, in BTTVBadgeUserSlice) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v11, v12 := range in {
+			if v11 > 0 {
+				out.RawByte(',')
+			}
+			easyjson515ca9ccEncodeGithubComJdavasligilEmodl5(out, v12)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BTTVBadgeUserSlice) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson515ca9ccEncodeGithubComJdavasligilEmodl4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BTTVBadgeUserSlice) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson515ca9ccEncodeGithubComJdavasligilEmodl4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BTTVBadgeUserSlice) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson515ca9ccDecodeGithubComJdavasligilEmodl4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BTTVBadgeUserSlice) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson515ca9ccDecodeGithubComJdavasligilEmodl4(l, v)
+}
+func easyjson515ca9ccDecodeGithubComJdavasligilEmodl5(in *jlexer.Lexer, out *BTTVBadgeUser) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "providerId":
+			out.ProviderID = string(in.String())
+		case "badge":
+			easyjson515ca9ccDecode(in, &out.Badge)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson515ca9ccEncodeGithubComJdavasligilEmodl5(out *jwriter.Writer, in BTTVBadgeUser) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"providerId\":"
+		out.RawString(prefix)
+		out.String(string(in.ProviderID))
+	}
+	{
+		const prefix string = ",\"badge\":"
+		out.RawString(prefix)
+		easyjson515ca9ccEncode(out, in.Badge)
+	}
+	out.RawByte('}')
+}
+func easyjson515ca9ccDecode(in *jlexer.Lexer, out *struct {
+	Type        int    `json:"type"`
+	Description string `json:"description"`
+	SVG         string `json:"svg"`
+}) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = int(in.Int())
+		case "description":
+			out.Description = string(in.String())
+		case "svg":
+			out.SVG = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson515ca9ccEncode(out *jwriter.Writer, in struct {
+	Type        int    `json:"type"`
+	Description string `json:"description"`
+	SVG         string `json:"svg"`
+}) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Type))
+	}
+	{
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"svg\":"
+		out.RawString(prefix)
+		out.String(string(in.SVG))
+	}
+	out.RawByte('}')
 }
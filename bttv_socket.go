@@ -0,0 +1,178 @@
+package emodl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+	"github.com/mailru/easyjson"
+)
+
+const bttvSocketURL = "wss://sockets.betterttv.net/ws"
+
+// Events of the BTTV socket.
+const (
+	bttvJoinChannel = "join_channel"
+	bttvEmoteCreate = "emote_create"
+	bttvEmoteUpdate = "emote_update"
+	bttvEmoteDelete = "emote_delete"
+)
+
+//easyjson:json
+type bttvSocketMessage struct {
+	Name string              `json:"name"`
+	Data easyjson.RawMessage `json:"data"`
+}
+
+//easyjson:json
+type bttvSocketChannel struct {
+	Name string `json:"name"`
+}
+
+// Data of emote events. Updates only hold the ID and new code of the emote
+// and deletes only its ID.
+//
+//easyjson:json
+type bttvSocketEmoteEvent struct {
+	Channel string    `json:"channel"`
+	Emote   BTTVEmote `json:"emote"`
+	EmoteID string    `json:"emoteId"`
+}
+
+// BTTVSocketClient keeps the BTTV channel emotes of a Downloader up to date
+// with the emote events of the BTTV socket.
+//
+// Changes missed while disconnected are not applied. Running Watch
+// alongside catches up on them.
+type BTTVSocketClient struct {
+	ed  *Downloader
+	opt LiveOptions
+}
+
+// NewBTTVSocketClient returns a client for the BTTV channel configured in
+// the options of ed. Load ed first so that changes apply to the loaded
+// emotes.
+func NewBTTVSocketClient(ed *Downloader, opt LiveOptions) *BTTVSocketClient {
+	opt.setDefaults(bttvSocketURL)
+	return &BTTVSocketClient{ed: ed, opt: opt}
+}
+
+// Run joins the channel and applies its emote events until ctx is done.
+// Dropped connections are retried with exponential backoff. Always returns
+// the error of ctx.
+func (c *BTTVSocketClient) Run(ctx context.Context) error {
+	return runLive(ctx, c.opt, c.connect)
+}
+
+// Start runs the client in a new goroutine and returns a channel receiving
+// the changes of every event. The channel is closed once ctx is done.
+//
+// The OnChange option is replaced. Events wait for the changes to be
+// received.
+func (c *BTTVSocketClient) Start(ctx context.Context) <-chan []EmoteDiff {
+	return startLive(ctx, &c.opt, c.Run)
+}
+
+func (c *BTTVSocketClient) connect(ctx context.Context) (bool, bool, error) {
+	opt := c.ed.Options.BTTV
+	if opt == nil {
+		return false, false, errors.New("emodl: no BTTV channel configured")
+	}
+	channel := opt.Platform + ":" + opt.PlatformID
+
+	conn, closeConn, err := dialLive(ctx, c.opt, c.ed.Options.UserAgent)
+	if err != nil {
+		return false, false, fmt.Errorf("emodl: %v: failure connecting to BTTV socket", err)
+	}
+	defer closeConn()
+
+	if err := c.write(conn, bttvJoinChannel, &bttvSocketChannel{Name: channel}); err != nil {
+		return false, false, err
+	}
+
+	for received := false; ; received = true {
+		_, b, err := conn.ReadMessage()
+		if err != nil {
+			return received, false, fmt.Errorf("emodl: %v: failure reading from BTTV socket", err)
+		}
+		var msg bttvSocketMessage
+		if err := easyjson.Unmarshal(b, &msg); err != nil {
+			return true, false, fmt.Errorf("emodl: %v: failure decoding BTTV socket message", err)
+		}
+
+		switch msg.Name {
+		case bttvEmoteCreate, bttvEmoteUpdate, bttvEmoteDelete:
+		default:
+			continue
+		}
+		var ev bttvSocketEmoteEvent
+		if err := easyjson.Unmarshal(msg.Data, &ev); err != nil {
+			c.onError(fmt.Errorf("emodl: %v: failure decoding BTTV %s", err, msg.Name))
+			continue
+		}
+		if ev.Channel != channel {
+			continue
+		}
+
+		var patch sourcePatch
+		switch msg.Name {
+		case bttvEmoteCreate:
+			patch.add = append(patch.add, ev.Emote)
+		case bttvEmoteUpdate:
+			e, ok := c.loaded(ev.Emote.ID)
+			if !ok {
+				c.onError(fmt.Errorf("emodl: updated BTTV emote %s is not loaded", ev.Emote.ID))
+				continue
+			}
+			e.Name = ev.Emote.Name
+			patch.update = append(patch.update, e)
+		case bttvEmoteDelete:
+			patch.remove = append(patch.remove, ev.EmoteID)
+		}
+		c.apply(patch)
+	}
+}
+
+func (c *BTTVSocketClient) apply(patch sourcePatch) {
+	d, err := c.ed.applyPatch(ProviderBTTV, true, patch)
+	if err != nil {
+		c.onError(err)
+	}
+	if !d.Empty() && c.opt.OnChange != nil {
+		c.opt.OnChange([]EmoteDiff{d})
+	}
+}
+
+func (c *BTTVSocketClient) onError(err error) {
+	if c.opt.OnError != nil {
+		c.opt.OnError(err)
+	}
+}
+
+// Returns a loaded channel emote by ID.
+func (c *BTTVSocketClient) loaded(id string) (BTTVEmote, bool) {
+	c.ed.mu.RLock()
+	defer c.ed.mu.RUnlock()
+	for _, pe := range c.ed.sources[sourceKey{provider: ProviderBTTV, channel: true}].native {
+		if e, ok := pe.(BTTVEmote); ok && e.ID == id {
+			return e, true
+		}
+	}
+	return BTTVEmote{}, false
+}
+
+func (c *BTTVSocketClient) write(conn *websocket.Conn, name string, v easyjson.Marshaler) error {
+	data, err := easyjson.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b, err := easyjson.Marshal(&bttvSocketMessage{Name: name, Data: data})
+	if err != nil {
+		return err
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, b); err != nil {
+		return fmt.Errorf("emodl: %v: failure writing to BTTV socket", err)
+	}
+	return nil
+}
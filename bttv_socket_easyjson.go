@@ -0,0 +1,390 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package emodl
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonEf511806DecodeGithubComJdavasligilEmodl(in *jlexer.Lexer, out *bttvSocketMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "data":
+			(out.Data).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf511806EncodeGithubComJdavasligilEmodl(out *jwriter.Writer, in bttvSocketMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		(in.Data).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v bttvSocketMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEf511806EncodeGithubComJdavasligilEmodl(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v bttvSocketMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEf511806EncodeGithubComJdavasligilEmodl(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *bttvSocketMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEf511806DecodeGithubComJdavasligilEmodl(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *bttvSocketMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEf511806DecodeGithubComJdavasligilEmodl(l, v)
+}
+func easyjsonEf511806DecodeGithubComJdavasligilEmodl1(in *jlexer.Lexer, out *bttvSocketEmoteEvent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "channel":
+			out.Channel = string(in.String())
+		case "emote":
+			easyjsonEf511806DecodeGithubComJdavasligilEmodl2(in, &out.Emote)
+		case "emoteId":
+			out.EmoteID = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf511806EncodeGithubComJdavasligilEmodl1(out *jwriter.Writer, in bttvSocketEmoteEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"channel\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Channel))
+	}
+	{
+		const prefix string = ",\"emote\":"
+		out.RawString(prefix)
+		easyjsonEf511806EncodeGithubComJdavasligilEmodl2(out, in.Emote)
+	}
+	{
+		const prefix string = ",\"emoteId\":"
+		out.RawString(prefix)
+		out.String(string(in.EmoteID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v bttvSocketEmoteEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEf511806EncodeGithubComJdavasligilEmodl1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v bttvSocketEmoteEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEf511806EncodeGithubComJdavasligilEmodl1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *bttvSocketEmoteEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEf511806DecodeGithubComJdavasligilEmodl1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *bttvSocketEmoteEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEf511806DecodeGithubComJdavasligilEmodl1(l, v)
+}
+func easyjsonEf511806DecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *BTTVEmote) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "code":
+			out.Name = string(in.String())
+		case "animated":
+			out.Animated = bool(in.Bool())
+		case "modifier":
+			out.Modifier = bool(in.Bool())
+		case "userId":
+			out.UserID = string(in.StringIntern())
+		case "user":
+			if in.IsNull() {
+				in.Skip()
+				out.User = nil
+			} else {
+				if out.User == nil {
+					out.User = new(BTTVEmoteUser)
+				}
+				easyjsonEf511806DecodeGithubComJdavasligilEmodl3(in, out.User)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf511806EncodeGithubComJdavasligilEmodl2(out *jwriter.Writer, in BTTVEmote) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"animated\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Animated))
+	}
+	{
+		const prefix string = ",\"modifier\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Modifier))
+	}
+	{
+		const prefix string = ",\"userId\":"
+		out.RawString(prefix)
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		if in.User == nil {
+			out.RawString("null")
+		} else {
+			easyjsonEf511806EncodeGithubComJdavasligilEmodl3(out, *in.User)
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonEf511806DecodeGithubComJdavasligilEmodl3(in *jlexer.Lexer, out *BTTVEmoteUser) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "displayName":
+			out.DisplayName = string(in.String())
+		case "providerId":
+			out.ProviderID = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf511806EncodeGithubComJdavasligilEmodl3(out *jwriter.Writer, in BTTVEmoteUser) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"displayName\":"
+		out.RawString(prefix)
+		out.String(string(in.DisplayName))
+	}
+	{
+		const prefix string = ",\"providerId\":"
+		out.RawString(prefix)
+		out.String(string(in.ProviderID))
+	}
+	out.RawByte('}')
+}
+func easyjsonEf511806DecodeGithubComJdavasligilEmodl4(in *jlexer.Lexer, out *bttvSocketChannel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf511806EncodeGithubComJdavasligilEmodl4(out *jwriter.Writer, in bttvSocketChannel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v bttvSocketChannel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEf511806EncodeGithubComJdavasligilEmodl4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v bttvSocketChannel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEf511806EncodeGithubComJdavasligilEmodl4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *bttvSocketChannel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEf511806DecodeGithubComJdavasligilEmodl4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *bttvSocketChannel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEf511806DecodeGithubComJdavasligilEmodl4(l, v)
+}
@@ -0,0 +1,158 @@
+package emodl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestBTTVSocketClient(t *testing.T) {
+	t.Parallel()
+
+	join := func(conn *websocket.Conn) bool {
+		var msg struct {
+			Name string `json:"name"`
+			Data struct {
+				Name string `json:"name"`
+			} `json:"data"`
+		}
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Error(err)
+			return false
+		}
+		if msg.Name != "join_channel" || msg.Data.Name != "twitch:"+fakeTwitchID {
+			t.Errorf("Unexpected join %+v", msg)
+			return false
+		}
+		return true
+	}
+	event := func(conn *websocket.Conn, name string, data map[string]any) {
+		if data["channel"] == nil {
+			data["channel"] = "twitch:" + fakeTwitchID
+		}
+		conn.WriteJSON(map[string]any{"name": name, "data": data})
+	}
+
+	socket := newFakeSocket(t, func(n int, conn *websocket.Conn) {
+		if !join(conn) {
+			return
+		}
+		switch n {
+		case 0:
+			event(conn, "emote_create", map[string]any{"channel": "twitch:1", "emote": map[string]any{
+				"id": "0", "code": "OtherChannel",
+			}})
+			event(conn, "emote_create", map[string]any{"emote": map[string]any{
+				"id": "5e4e7a1f08b4447d56a92967", "code": "peepoDance", "imageType": "gif", "animated": true,
+			}})
+			event(conn, "emote_update", map[string]any{"emote": map[string]any{
+				"id": "5e1a76dd8af14b5f1b438c04", "code": "monkaW",
+			}})
+			event(conn, "emote_delete", map[string]any{"emoteId": "5f0901cba2ac620530368579"})
+			// Hang up so the client reconnects
+		case 1:
+			event(conn, "emote_delete", map[string]any{"emoteId": "5f1b0186cf6d2144653d2970"})
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}
+	})
+
+	srv := newFakeServer(t)
+	ed := NewDownloader(fakeChannelOptions(srv))
+	if _, err := ed.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	clock := newManualClock()
+	client := NewBTTVSocketClient(&ed, LiveOptions{
+		URL:        fakeSocketURL(socket),
+		MinBackoff: time.Second,
+		Clock:      clock,
+	})
+	changes := client.Start(ctx)
+
+	expect := func(check func(d EmoteDiff) bool) {
+		t.Helper()
+		diffs := <-changes
+		if len(diffs) != 1 || diffs[0].Provider != ProviderBTTV || !check(diffs[0]) {
+			t.Fatalf("Unexpected changes %+v", diffs)
+		}
+	}
+	expect(func(d EmoteDiff) bool {
+		return len(d.Added) == 1 && d.Added[0].Name == "peepoDance"
+	})
+	expect(func(d EmoteDiff) bool {
+		return len(d.Renamed) == 1 && d.Renamed[0].OldName == "monkaS" && d.Renamed[0].Emote.Name == "monkaW"
+	})
+	expect(func(d EmoteDiff) bool {
+		return len(d.Removed) == 1 && d.Removed[0].Name == "modCheck"
+	})
+
+	ed.RLock()
+	_, added := ed.BTTVEmotes["peepoDance"]
+	_, removed := ed.BTTVEmotes["modCheck"]
+	_, other := ed.BTTVEmotes["OtherChannel"]
+	ed.RUnlock()
+	_, renamed := ed.Emotes()["monkaW"]
+	if !added || !renamed || removed || other {
+		t.Error("Emote maps not updated by events")
+	}
+
+	if d := <-clock.waits; d != time.Second {
+		t.Fatalf("Expected reconnect after 1s, waited %v", d)
+	}
+	clock.ticks <- time.Now()
+	expect(func(d EmoteDiff) bool {
+		return len(d.Removed) == 1 && d.Removed[0].Name == "catJAM"
+	})
+
+	cancel()
+	if _, ok := <-changes; ok {
+		t.Fatal("Changes after cancel")
+	}
+}
+
+func TestBTTVSocketClientBackoff(t *testing.T) {
+	t.Parallel()
+
+	// Every connection is dropped right after joining
+	socket := newFakeSocket(t, func(n int, conn *websocket.Conn) {
+		conn.ReadMessage()
+	})
+
+	srv := newFakeServer(t)
+	ed := NewDownloader(fakeChannelOptions(srv))
+	if _, err := ed.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	clock := newManualClock()
+	client := NewBTTVSocketClient(&ed, LiveOptions{
+		URL:        fakeSocketURL(socket),
+		MinBackoff: time.Second,
+		MaxBackoff: 4 * time.Second,
+		Clock:      clock,
+	})
+	changes := client.Start(ctx)
+
+	for _, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		if d := <-clock.waits; d != want {
+			t.Fatalf("Expected reconnect after %v, waited %v", want, d)
+		}
+		clock.ticks <- time.Now()
+	}
+
+	cancel()
+	if _, ok := <-changes; ok {
+		t.Fatal("Changes after cancel")
+	}
+}
@@ -1,12 +1,13 @@
 package emodl
 
 import (
+	"context"
 	"testing"
 )
 
 func TestGetGlobalBTTVEmotes(t *testing.T) {
 	t.Parallel()
-	bttvEmotes, err := getBTTVGlobalEmotes()
+	bttvEmotes, err := getBTTVGlobalEmotes(context.Background(), fakeAPIClient(t, ProviderBTTV))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -38,14 +39,25 @@ func TestGetGlobalBTTVEmotes(t *testing.T) {
 
 func TestGetBTTVUserEmotes(t *testing.T) {
 	t.Parallel()
-	bttvEmotes, err := getBTTVUserEmotes("twitch", "39226538")
+	c := fakeAPIClient(t, ProviderBTTV)
+	bttvEmotes, err := getBTTVUserEmotes(context.Background(), c, "twitch", fakeTwitchID)
 	if err != nil {
 		t.Fatal(err)
 	}
 	if bttvEmotes == nil {
 		t.Fatal("bttv emotes can not be nil")
 	}
-	if len(bttvEmotes) == 0 {
-		t.Fatal("No emotes obtained")
+	if len(bttvEmotes) != 4 {
+		t.Fatalf("Expected 4 shared and channel emotes, got %d", len(bttvEmotes))
 	}
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		bttvEmotes, err := getBTTVUserEmotes(context.Background(), c, "twitch", "0")
+		if err == nil || err.Error() != "user not found" {
+			t.Fatalf("Expected error message from API, got %v", err)
+		}
+		if bttvEmotes == nil || len(bttvEmotes) != 0 {
+			t.Fatalf("Expected empty emote slice on error, got %v", bttvEmotes)
+		}
+	})
 }
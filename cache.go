@@ -0,0 +1,312 @@
+package emodl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mailru/easyjson"
+)
+
+// TTL key of cached images in DiskCacheOptions.TTLs.
+const CacheImages = "images"
+
+const defaultCacheTTL = time.Hour
+
+type DiskCacheOptions struct {
+	// Directory the cache is stored in
+	Dir string
+
+	// Size budget of all cached content in bytes. Least recently used
+	// entries are evicted once it is exceeded. Zero means no limit.
+	MaxBytes int64
+
+	// How long entries stay fresh indexed by provider name, or CacheImages
+	// for image bytes. Missing entries use DefaultTTL.
+	TTLs map[string]time.Duration
+
+	// How long entries without a TTL stay fresh (1 hour if zero)
+	DefaultTTL time.Duration
+}
+
+// DiskCache is a persistent cache of provider API responses and image bytes.
+//
+// Content is stored once under its SHA-256 hash in {Dir}/blobs and every
+// key (a request URL or Image.ID) is indexed in {Dir}/index pointing at
+// its content. Index file modification times record the last access so
+// least recently used entries survive restarts.
+type DiskCache struct {
+	opt DiskCacheOptions
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	refs    map[string]int
+	size    int64
+}
+
+//easyjson:json
+type cacheEntry struct {
+	Key         string `json:"key"`
+	Provider    string `json:"provider"`
+	Blob        string `json:"blob"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Stored      int64  `json:"stored"`
+
+	accessed time.Time
+}
+
+// NewDiskCache opens the cache in opt.Dir, creating it if needed.
+func NewDiskCache(opt DiskCacheOptions) (*DiskCache, error) {
+	if opt.Dir == "" {
+		return nil, errors.New("emodl: disk cache needs a directory")
+	}
+	if opt.DefaultTTL <= 0 {
+		opt.DefaultTTL = defaultCacheTTL
+	}
+	c := &DiskCache{
+		opt:     opt,
+		now:     time.Now,
+		entries: make(map[string]*cacheEntry, 256),
+		refs:    make(map[string]int, 256),
+	}
+	for _, dir := range []string{c.indexDir(), c.blobDir()} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	err := filepath.WalkDir(c.indexDir(), func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		e := &cacheEntry{}
+		info, infoErr := d.Info()
+		if easyjson.Unmarshal(b, e) != nil || infoErr != nil {
+			// Partially written or foreign file
+			return os.Remove(p)
+		}
+		if _, err := os.Stat(c.blobPath(e.Blob)); err != nil {
+			return os.Remove(p)
+		}
+		e.accessed = info.ModTime()
+		c.add(e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Content no longer referenced by any key
+	blobs, err := os.ReadDir(c.blobDir())
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range blobs {
+		if c.refs[b.Name()] == 0 {
+			if err := removeFile(c.blobPath(b.Name())); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return c, c.evict()
+}
+
+// Get returns the cached content of a key and its content type. Entries
+// older than the TTL of their provider are not returned.
+func (c *DiskCache) Get(key string) ([]byte, string, bool) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok && c.now().Sub(time.Unix(e.Stored, 0)) >= c.ttl(e.Provider) {
+		ok = false
+	}
+	var accessed time.Time
+	if ok {
+		e.accessed = c.now()
+		accessed = e.accessed
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, "", false
+	}
+
+	b, err := os.ReadFile(c.blobPath(e.Blob))
+	if err != nil {
+		return nil, "", false
+	}
+	os.Chtimes(c.indexPath(key), time.Time{}, accessed)
+	return b, e.ContentType, true
+}
+
+// Put stores the content of a key on behalf of a provider, or CacheImages.
+func (c *DiskCache) Put(provider string, key string, contentType string, b []byte) error {
+	sum := sha256.Sum256(b)
+	e := &cacheEntry{
+		Key:         key,
+		Provider:    provider,
+		Blob:        hex.EncodeToString(sum[:]),
+		ContentType: contentType,
+		Size:        int64(len(b)),
+		Stored:      c.now().Unix(),
+		accessed:    c.now(),
+	}
+
+	index, err := easyjson.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	// Blobs are written and referenced under the lock, so another Put
+	// releasing the last reference to the same content cannot remove it
+	// in between.
+	c.mu.Lock()
+	err = c.store(e, b, index)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return c.evict()
+}
+
+// Writes the blob and index file of an entry and references it in place of
+// the entry of its key. c.mu must be held.
+func (c *DiskCache) store(e *cacheEntry, b []byte, index []byte) error {
+	if _, err := os.Stat(c.blobPath(e.Blob)); errors.Is(err, fs.ErrNotExist) {
+		if err := writeFileAtomic(c.blobPath(e.Blob), b); err != nil {
+			return err
+		}
+	}
+	if err := writeFileAtomic(c.indexPath(e.Key), index); err != nil {
+		return err
+	}
+
+	var err error
+	if old, ok := c.entries[e.Key]; ok && c.remove(old) && old.Blob != e.Blob {
+		err = removeFile(c.blobPath(old.Blob))
+	}
+	c.add(e)
+	return err
+}
+
+// Size returns the number of bytes of content in the cache.
+func (c *DiskCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+func (c *DiskCache) ttl(provider string) time.Duration {
+	if ttl, ok := c.opt.TTLs[provider]; ok {
+		return ttl
+	}
+	return c.opt.DefaultTTL
+}
+
+// Entries sharing content only count its size once.
+func (c *DiskCache) add(e *cacheEntry) {
+	c.entries[e.Key] = e
+	if c.refs[e.Blob] == 0 {
+		c.size += e.Size
+	}
+	c.refs[e.Blob]++
+}
+
+// Returns true if the content of e is no longer referenced.
+func (c *DiskCache) remove(e *cacheEntry) bool {
+	delete(c.entries, e.Key)
+	c.refs[e.Blob]--
+	if c.refs[e.Blob] > 0 {
+		return false
+	}
+	delete(c.refs, e.Blob)
+	c.size -= e.Size
+	return true
+}
+
+// Removes least recently used entries until the cache fits its budget.
+func (c *DiskCache) evict() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.opt.MaxBytes <= 0 || c.size <= c.opt.MaxBytes {
+		return nil
+	}
+
+	lru := make([]*cacheEntry, 0, len(c.entries))
+	for _, e := range c.entries {
+		lru = append(lru, e)
+	}
+	sort.Slice(lru, func(i, j int) bool {
+		return lru[i].accessed.Before(lru[j].accessed)
+	})
+
+	var errs []error
+	for _, e := range lru {
+		if c.size <= c.opt.MaxBytes {
+			break
+		}
+		errs = append(errs, removeFile(c.indexPath(e.Key)))
+		if c.remove(e) {
+			errs = append(errs, removeFile(c.blobPath(e.Blob)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *DiskCache) indexDir() string {
+	return filepath.Join(c.opt.Dir, "index")
+}
+
+func (c *DiskCache) blobDir() string {
+	return filepath.Join(c.opt.Dir, "blobs")
+}
+
+func (c *DiskCache) indexPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.indexDir(), hex.EncodeToString(sum[:]))
+}
+
+func (c *DiskCache) blobPath(blob string) string {
+	return filepath.Join(c.blobDir(), blob)
+}
+
+// Writes to a temporary file first so readers never see partial content.
+func writeFileAtomic(name string, b []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), name)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("emodl: %v: failure writing cache file %s", err, name)
+	}
+	return nil
+}
+
+func removeFile(name string) error {
+	err := os.Remove(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,120 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package emodl
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonA591d1bcDecodeGithubComJdavasligilEmodl(in *jlexer.Lexer, out *cacheEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "key":
+			out.Key = string(in.String())
+		case "provider":
+			out.Provider = string(in.String())
+		case "blob":
+			out.Blob = string(in.String())
+		case "content_type":
+			out.ContentType = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "stored":
+			out.Stored = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA591d1bcEncodeGithubComJdavasligilEmodl(out *jwriter.Writer, in cacheEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"key\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Key))
+	}
+	{
+		const prefix string = ",\"provider\":"
+		out.RawString(prefix)
+		out.String(string(in.Provider))
+	}
+	{
+		const prefix string = ",\"blob\":"
+		out.RawString(prefix)
+		out.String(string(in.Blob))
+	}
+	{
+		const prefix string = ",\"content_type\":"
+		out.RawString(prefix)
+		out.String(string(in.ContentType))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"stored\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Stored))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v cacheEntry) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA591d1bcEncodeGithubComJdavasligilEmodl(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v cacheEntry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA591d1bcEncodeGithubComJdavasligilEmodl(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *cacheEntry) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA591d1bcDecodeGithubComJdavasligilEmodl(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *cacheEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA591d1bcDecodeGithubComJdavasligilEmodl(l, v)
+}
@@ -0,0 +1,218 @@
+package emodl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func newTestDiskCache(t *testing.T, opt DiskCacheOptions, clock *fakeClock) *DiskCache {
+	t.Helper()
+	c, err := NewDiskCache(opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clock != nil {
+		c.now = clock.now
+	}
+	return c
+}
+
+func TestDiskCacheTTL(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{t: time.Unix(1745000000, 0)}
+	c := newTestDiskCache(t, DiskCacheOptions{
+		Dir:  t.TempDir(),
+		TTLs: map[string]time.Duration{ProviderSevenTV: time.Minute},
+	}, clock)
+
+	if err := c.Put(ProviderSevenTV, "7tv", "application/json", []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Put(ProviderBTTV, "bttv", "application/json", []byte(`[]`)); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.t = clock.t.Add(2 * time.Minute)
+	if _, _, ok := c.Get("7tv"); ok {
+		t.Fatal("7TV entry still fresh after its TTL")
+	}
+	b, contentType, ok := c.Get("bttv")
+	if !ok || string(b) != "[]" || contentType != "application/json" {
+		t.Fatalf("BTTV entry with default TTL missing: %q %q %v", b, contentType, ok)
+	}
+}
+
+func TestDiskCacheLRU(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	clock := &fakeClock{t: time.Unix(1745000000, 0)}
+	c := newTestDiskCache(t, DiskCacheOptions{Dir: dir, MaxBytes: 10}, clock)
+
+	put := func(key string, content string) {
+		t.Helper()
+		clock.t = clock.t.Add(time.Second)
+		if err := c.Put(CacheImages, key, "image/png", []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	put("a", "aaaa")
+	put("b", "bbbb")
+	// Same content as a is only stored once
+	put("c", "aaaa")
+	if c.Size() != 8 {
+		t.Fatalf("Expected 8 bytes of content, got %d", c.Size())
+	}
+
+	clock.t = clock.t.Add(time.Second)
+	if _, _, ok := c.Get("b"); !ok {
+		t.Fatal("Entry b missing")
+	}
+	put("d", "dddd")
+
+	for key, want := range map[string]bool{"a": false, "b": true, "c": false, "d": true} {
+		if _, _, ok := c.Get(key); ok != want {
+			t.Errorf("Entry %s cached: %v, want %v", key, ok, want)
+		}
+	}
+	blobs, err := os.ReadDir(filepath.Join(dir, "blobs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blobs) != 2 {
+		t.Fatalf("Expected 2 blobs after eviction, got %d", len(blobs))
+	}
+
+	t.Run("Reopen", func(t *testing.T) {
+		c := newTestDiskCache(t, DiskCacheOptions{Dir: dir, MaxBytes: 10}, clock)
+		if c.Size() != 8 {
+			t.Fatalf("Expected 8 bytes of content after reopening, got %d", c.Size())
+		}
+		if _, _, ok := c.Get("d"); !ok {
+			t.Fatal("Entry d missing after reopening")
+		}
+	})
+}
+
+func TestDiskCacheConcurrentPut(t *testing.T) {
+	t.Parallel()
+	c := newTestDiskCache(t, DiskCacheOptions{Dir: t.TempDir()}, nil)
+
+	// Keys moving between two contents release and take the same blobs
+	contents := [][]byte{[]byte("first"), []byte("second")}
+	var wg sync.WaitGroup
+	for k, key := range []string{"a", "b", "c", "d"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 10 {
+				if err := c.Put(ProviderBTTV, key, "text/plain", contents[(i+k)%2]); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		if _, _, ok := c.Get(key); !ok {
+			t.Errorf("%s: content missing", key)
+		}
+	}
+	if c.Size() != int64(len(contents[0])+len(contents[1])) {
+		t.Errorf("Expected both contents counted once, got %d bytes", c.Size())
+	}
+}
+
+func TestDiskCacheConcurrentGet(t *testing.T) {
+	t.Parallel()
+	c := newTestDiskCache(t, DiskCacheOptions{Dir: t.TempDir()}, nil)
+	if err := c.Put(ProviderBTTV, "a", "text/plain", []byte("content")); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				if _, _, ok := c.Get("a"); !ok {
+					t.Error("Content missing")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDownloaderDiskCache(t *testing.T) {
+	t.Parallel()
+
+	cache := newTestDiskCache(t, DiskCacheOptions{Dir: t.TempDir()}, nil)
+	srv := newFakeServer(t)
+	opt := fakeChannelOptions(srv)
+	opt.Cache = cache
+
+	ed := NewDownloader(opt)
+	cold, err := ed.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Loading again must not need the network
+	srv.Close()
+	ed = NewDownloader(opt)
+	warm, err := ed.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warm) != len(cold) {
+		t.Fatalf("Expected %d emotes from cache, got %d", len(cold), len(warm))
+	}
+
+	t.Run("Images", func(t *testing.T) {
+		pngBytes := testPNG(t, 28, 28)
+		requests := 0
+		mux := http.NewServeMux()
+		mux.HandleFunc("/emote/", func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Write(pngBytes)
+		})
+		img := httptest.NewServer(mux)
+		defer img.Close()
+
+		f := NewImageFetcher(img.Client(), "", 1)
+		f.cache = cache
+		for range 2 {
+			data, err := f.Fetch(context.Background(), Image{URL: img.URL + "/emote/1x", ID: "1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.ContentType != "image/png" || len(data.Bytes) != len(pngBytes) {
+				t.Fatalf("Unexpected image data: %s %d bytes", data.ContentType, len(data.Bytes))
+			}
+		}
+		if requests != 1 {
+			t.Fatalf("Expected 1 image request, got %d", requests)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package emodl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/mailru/easyjson"
+)
+
+// Default base URLs of the provider APIs.
+const (
+	bttvBaseURL    = "https://api.betterttv.net"
+	sevenTVBaseURL = "https://7tv.io"
+	ffzBaseURL     = "https://api.frankerfacez.com"
+	twitchBaseURL  = "https://api.twitch.tv"
+)
+
+// Sends the requests of a single provider API.
+type apiClient struct {
+	provider  string
+	client    *http.Client
+	baseURL   *url.URL
+	userAgent string
+	cache     *DiskCache
+
+	// Adds headers such as credentials to every request if set
+	header func(ctx context.Context, h http.Header) error
+
+	// Reported on every request if the base URL is invalid.
+	err error
+
+	mu         sync.Mutex
+	validators map[string]validator
+}
+
+// Validators of a response and the value decoded from it, reused when the
+// server reports the response as unchanged.
+type validator struct {
+	etag         string
+	lastModified string
+	value        reflect.Value
+}
+
+func newAPIClient(opt DownloaderOptions, provider string, defaultBaseURL string) *apiClient {
+	c := &apiClient{
+		provider:  provider,
+		client:    opt.Client,
+		userAgent: opt.UserAgent,
+		cache:     opt.Cache,
+
+		validators: make(map[string]validator, 8),
+	}
+	if c.client == nil {
+		c.client = http.DefaultClient
+	}
+
+	base, ok := opt.BaseURLs[provider]
+	if !ok || base == "" {
+		base = defaultBaseURL
+	}
+	c.baseURL, c.err = url.Parse(base)
+	if c.err == nil && (c.baseURL.Scheme == "" || c.baseURL.Host == "") {
+		c.err = fmt.Errorf("%s base URL %q must include a scheme and host", provider, base)
+	}
+	return c
+}
+
+// Returns the URL of an API path relative to the base URL. The path may
+// end with a query.
+func (c *apiClient) url(path string) *url.URL {
+	path, query, _ := strings.Cut(path, "?")
+	u := *c.baseURL
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawPath = ""
+	u.RawQuery = query
+	return &u
+}
+
+// Key of the context value set by revalidate.
+type revalidateKey struct{}
+
+// Returns a context whose requests are sent even if the disk cache has a
+// fresh response, so that reloads see changes within the TTL.
+func revalidate(ctx context.Context) context.Context {
+	return context.WithValue(ctx, revalidateKey{}, true)
+}
+
+// Sends a GET request for an API path and decodes the JSON response into v.
+// Fresh responses in the disk cache are used without sending a request,
+// unless ctx comes from revalidate.
+//
+// Requests are conditional if an earlier response had an ETag or
+// Last-Modified header. If the response is unchanged, v is set to the value
+// decoded from the earlier response, which shares its slices and maps.
+//
+// Error responses are decoded as a jsonError if possible.
+func (c *apiClient) getJSON(ctx context.Context, path string, v easyjson.Unmarshaler) error {
+	if c.err != nil {
+		return c.err
+	}
+	u := c.url(path).String()
+
+	if c.cache != nil && ctx.Value(revalidateKey{}) == nil {
+		if body, _, ok := c.cache.Get(u); ok && easyjson.Unmarshal(body, v) == nil {
+			return nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return err
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.header != nil {
+		if err := c.header(ctx, req.Header); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	prev, conditional := c.validators[u]
+	c.mu.Unlock()
+	if conditional {
+		if prev.etag != "" {
+			req.Header.Set("If-None-Match", prev.etag)
+		}
+		if prev.lastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.lastModified)
+		}
+	}
+
+	response, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	rv := reflect.ValueOf(v).Elem()
+	if response.StatusCode == http.StatusNotModified && conditional && prev.value.Type() == rv.Type() {
+		rv.Set(prev.value)
+		return nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		errorMessage := &jsonError{}
+		err = easyjson.Unmarshal(body, errorMessage)
+		if err != nil || errorMessage.Error.Message == "" {
+			return errors.New(response.Status + "\n" + string(body))
+		}
+		return errors.New(errorMessage.Error.Message)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	err = easyjson.Unmarshal(body, v)
+	if err != nil {
+		return err
+	}
+
+	etag, lastModified := response.Header.Get("ETag"), response.Header.Get("Last-Modified")
+	if etag != "" || lastModified != "" {
+		value := reflect.New(rv.Type()).Elem()
+		value.Set(rv)
+		c.mu.Lock()
+		c.validators[u] = validator{etag: etag, lastModified: lastModified, value: value}
+		c.mu.Unlock()
+	}
+
+	if c.cache == nil {
+		return nil
+	}
+	return c.cache.Put(c.provider, u, response.Header.Get("Content-Type"), body)
+}
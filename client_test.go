@@ -0,0 +1,99 @@
+package emodl
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestAPIClientOptions(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "emodl-test" {
+			t.Errorf("User-Agent: %q", r.Header.Get("User-Agent"))
+		}
+		switch r.URL.Path {
+		case "/mirror/3/cached/emotes/global":
+			w.Write([]byte(`[{"id":"54fa8f1401e468494b85b537","code":":tf:","animated":false}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"message":"Unknown Emote"}}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := newAPIClient(DownloaderOptions{
+		Client:    srv.Client(),
+		BaseURLs:  map[string]string{ProviderBTTV: srv.URL + "/mirror/"},
+		UserAgent: "emodl-test",
+	}, ProviderBTTV, bttvBaseURL)
+
+	es, err := getBTTVGlobalEmotes(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es) != 1 || es[0].Name != ":tf:" {
+		t.Fatalf("Unexpected emotes: %v", es)
+	}
+
+	_, err = getBTTVUser(context.Background(), c, "twitch", "0")
+	if err == nil || err.Error() != "Unknown Emote" {
+		t.Fatalf("Expected API error message, got %v", err)
+	}
+}
+
+func TestAPIClientInvalidBaseURL(t *testing.T) {
+	t.Parallel()
+
+	c := newAPIClient(DownloaderOptions{
+		BaseURLs: map[string]string{ProviderFFZ: "localhost:8080"},
+	}, ProviderFFZ, ffzBaseURL)
+
+	_, err := getFFZEmoteSets(context.Background(), c, "global")
+	if err == nil {
+		t.Fatal("No error with a base URL missing its scheme")
+	}
+}
+
+func TestAPIClientConditional(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	statuses := make(map[int]int, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := httptest.NewRecorder()
+		serveFixture(rec, r)
+		mu.Lock()
+		statuses[rec.Code]++
+		mu.Unlock()
+		maps.Copy(w.Header(), rec.Header())
+		w.WriteHeader(rec.Code)
+		w.Write(rec.Body.Bytes())
+	}))
+	defer srv.Close()
+
+	ed := NewDownloader(fakeChannelOptions(srv))
+	first, err := ed.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ed.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 7 endpoints are requested by every load
+	if statuses[http.StatusOK] != 7 || statuses[http.StatusNotModified] != 7 {
+		t.Fatalf("Expected 7 full and 7 conditional responses, got %v", statuses)
+	}
+	if len(first) != len(second) || len(second) != 22 {
+		t.Fatalf("Emotes differ after unchanged responses: %d and %d", len(first), len(second))
+	}
+	if _, ok := second["peepoHappy"]; !ok {
+		t.Fatal("7TV channel emote missing after unchanged responses")
+	}
+}
@@ -0,0 +1,154 @@
+// Command emodl-fixtures records the provider API responses used by emodl
+// into versioned fixture files and checks them for schema drift.
+//
+// Fixtures are written to {dir}/{host}/{path}.json, the layout of the
+// testdata served to the tests of emodl. Twitch emotes are only recorded
+// with the client ID of a Twitch application and an access token of it in
+// the TWITCH_TOKEN environment variable. Record them again for the channel
+// of the tests from the root of the module:
+//
+//	TWITCH_TOKEN=... emodl-fixtures -dir testdata -twitch 39226538 -7tv 1048391821 -twitch-client-id ...
+//
+// Record into a temporary directory and report schema drift against the
+// fixtures in -dir, exiting with status 1 if anything changed:
+//
+//	TWITCH_TOKEN=... emodl-fixtures -dir testdata -twitch 39226538 -7tv 1048391821 -twitch-client-id ... -check
+//
+// Fixtures in -dir that were not recorded again, such as hand written
+// error responses, are not reported.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jdavasligil/emodl"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// Runs the command and returns its exit status, so that deferred cleanup
+// happens before exiting.
+func run() int {
+	dir := flag.String("dir", "testdata", "fixture directory")
+	check := flag.Bool("check", false, "report schema drift instead of overwriting fixtures")
+	platform := flag.String("platform", "twitch", "platform of the channel IDs")
+	channelID := flag.String("twitch", "", "channel ID used for BTTV, 7TV and FFZ")
+	sevenTVID := flag.String("7tv", "", "channel ID used for 7TV (defaults to -twitch)")
+	youtubeID := flag.String("youtube", "", "YouTube channel ID to record FFZ room/yt for")
+	twitchClientID := flag.String("twitch-client-id", "", "client ID of the Twitch application used for Twitch emotes (token in $TWITCH_TOKEN)")
+	timeout := flag.Duration("timeout", 30*time.Second, "timeout of every load")
+	flag.Parse()
+
+	if *sevenTVID == "" {
+		*sevenTVID = *channelID
+	}
+
+	out := *dir
+	if *check {
+		tmp, err := os.MkdirTemp("", "emodl-fixtures")
+		if err != nil {
+			log.Print(err)
+			return 1
+		}
+		defer os.RemoveAll(tmp)
+		out = tmp
+	}
+
+	client := &http.Client{Transport: &emodl.RecordTransport{Dir: out}}
+
+	var twitch *emodl.TwitchOptions
+	if *twitchClientID != "" {
+		token := os.Getenv("TWITCH_TOKEN")
+		if token == "" {
+			log.Print("TWITCH_TOKEN must be set with -twitch-client-id")
+			return 1
+		}
+		twitch = &emodl.TwitchOptions{
+			ClientID: *twitchClientID,
+			Token:    func(context.Context) (string, error) { return token, nil },
+		}
+	} else {
+		log.Print("Twitch emotes not recorded without -twitch-client-id")
+	}
+
+	var opts []emodl.DownloaderOptions
+	if *channelID != "" {
+		opt := emodl.DownloaderOptions{
+			BTTV:    &emodl.BTTVOptions{Platform: *platform, PlatformID: *channelID},
+			SevenTV: &emodl.SevenTVOptions{Platform: *platform, PlatformID: *sevenTVID},
+			FFZ:     &emodl.FFZOptions{Platform: *platform, PlatformID: *channelID},
+		}
+		if twitch != nil && *platform == "twitch" {
+			opt.Twitch = &emodl.TwitchOptions{ClientID: twitch.ClientID, Token: twitch.Token, BroadcasterID: *channelID}
+		}
+		opts = append(opts, opt)
+	}
+	if *youtubeID != "" {
+		opts = append(opts, emodl.DownloaderOptions{
+			FFZ: &emodl.FFZOptions{Platform: "youtube", PlatformID: *youtubeID},
+		})
+	}
+	if len(opts) == 0 {
+		// Global emotes only
+		opts = append(opts, emodl.DownloaderOptions{Twitch: twitch})
+	}
+
+	failed := false
+	for _, opt := range opts {
+		opt.Client = client
+		opt.UserAgent = "emodl-fixtures"
+		ed := emodl.NewDownloader(opt)
+
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		_, err := ed.LoadContext(ctx)
+		err = errors.Join(err, ed.LoadBadgesContext(ctx), ed.LoadPaintsContext(ctx))
+		cancel()
+		if err != nil {
+			// Error responses are recorded too, so keep going.
+			log.Print(err)
+			failed = true
+		}
+	}
+
+	if !*check {
+		if failed {
+			return 1
+		}
+		return 0
+	}
+
+	drifts, err := emodl.CompareFixtures(*dir, out)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+	reported := 0
+	for _, d := range drifts {
+		if d.Status[1] == 0 {
+			// Not recorded by this run
+			continue
+		}
+		reported++
+		fmt.Printf("%s (status %d -> %d)\n", d.Path, d.Status[0], d.Status[1])
+		if len(d.Added) > 0 {
+			fmt.Printf("\t+ %s\n", strings.Join(d.Added, "\n\t+ "))
+		}
+		if len(d.Removed) > 0 {
+			fmt.Printf("\t- %s\n", strings.Join(d.Removed, "\n\t- "))
+		}
+	}
+	if failed || reported > 0 {
+		return 1
+	}
+	return 0
+}
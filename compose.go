@@ -0,0 +1,152 @@
+package emodl
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// EmoteTransform is a set of changes to how an emote is drawn.
+type EmoteTransform int
+
+const (
+	// Mirrored horizontally
+	TransformFlipX EmoteTransform = 1 << iota
+	// Mirrored vertically
+	TransformFlipY
+	// Stretched to twice its width
+	TransformWide
+)
+
+// Text modifiers of BTTV written as a word before an emote, e.g. "w! catJAM".
+// The zero-width modifier z! has no transform.
+var bttvModifiers = map[string]EmoteTransform{
+	"w!": TransformWide,
+	"h!": TransformFlipX,
+	"v!": TransformFlipY,
+	"z!": 0,
+}
+
+// Compose groups the emote tokens of a tokenized message with their
+// modifiers and the zero-width emotes drawn over them:
+//
+//   - Zero-width emotes right after an emote are added to its Overlays.
+//   - Modifier emotes right after an emote add their Transform to it, and
+//     are also added to its Overlays if they are zero-width.
+//   - BTTV text modifiers (w!, h!, v! and z!) right before an emote apply to
+//     it, and z! makes it zero-width.
+//
+// Emotes are right after one another if only white space is between them.
+// Zero-width and modifier emotes with no emote before them stand alone.
+//
+// Tokens grouped together become a single emote token covering all of
+// them, so joining the Text of the tokens still gives back the message.
+func Compose(tokens []Token) []Token {
+	out := make([]Token, 0, len(tokens))
+	for _, tok := range tokens {
+		if tok.Kind != TokenEmote {
+			if n := len(out); n > 0 && out[n-1].Kind == TokenText {
+				out[n-1] = joinTokens(out[n-1], tok)
+			} else {
+				out = append(out, tok)
+			}
+			continue
+		}
+
+		zeroWidth := tok.Emote.ZeroWidth
+		if n := len(out); n > 0 && out[n-1].Kind == TokenText {
+			var text Token
+			var z bool
+			text, tok, z = takeBTTVModifiers(out[n-1], tok)
+			zeroWidth = zeroWidth || z
+			out = out[:n-1]
+			if text.Text != "" {
+				out = append(out, text)
+			}
+		}
+
+		base := previousEmote(out)
+		if base < 0 || !(zeroWidth || tok.Emote.Modifier) {
+			out = append(out, tok)
+			continue
+		}
+
+		b := out[base]
+		for _, t := range out[base+1:] {
+			b = joinTokens(b, t)
+		}
+		if tok.Emote.Modifier {
+			b.Transform |= tok.Emote.Transform
+		}
+		if zeroWidth {
+			overlay := tok
+			overlay.Overlays = nil
+			b.Overlays = append(b.Overlays, overlay)
+		}
+		out = append(out[:base], joinTokens(b, tok))
+	}
+	return out
+}
+
+// Compose tokenizes a chat message and composes its tokens. See Compose.
+func (m EmoteMap) Compose(message string) []Token {
+	return Compose(m.Tokenize(message))
+}
+
+// Compose tokenizes a chat message with the emotes of the last Load and
+// composes its tokens. See Compose.
+func (ed *Downloader) Compose(message string) []Token {
+	return Compose(ed.Tokenize(message))
+}
+
+// Returns the index of the last token of tokens if it is an emote, or of
+// the emote before it if only white space is between, or -1.
+func previousEmote(tokens []Token) int {
+	i := len(tokens) - 1
+	if i >= 0 && tokens[i].Kind == TokenText && strings.TrimFunc(tokens[i].Text, isWordSeparator) == "" {
+		i--
+	}
+	if i < 0 || tokens[i].Kind != TokenEmote {
+		return -1
+	}
+	return i
+}
+
+// Moves the BTTV modifier words at the end of a text token into the emote
+// token after it. Reports whether one of them was z!.
+func takeBTTVModifiers(text Token, emote Token) (Token, Token, bool) {
+	var zeroWidth bool
+	rest := text.Text
+	for {
+		trimmed := strings.TrimRightFunc(rest, isWordSeparator)
+		start := 0
+		if i := strings.LastIndexFunc(trimmed, isWordSeparator); i >= 0 {
+			_, size := utf8.DecodeRuneInString(trimmed[i:])
+			start = i + size
+		}
+		word := trimmed[start:]
+		transform, ok := bttvModifiers[word]
+		if !ok {
+			break
+		}
+		emote.Transform |= transform
+		zeroWidth = zeroWidth || word == "z!"
+		rest = trimmed[:start]
+	}
+	if len(rest) == len(text.Text) {
+		return text, emote, false
+	}
+
+	k := len(rest)
+	runes := utf8.RuneCountInString(rest)
+	emote.Text = text.Text[k:] + emote.Text
+	emote.Start, emote.RuneStart = text.Start+k, text.RuneStart+runes
+	text.Text, text.End, text.RuneEnd = rest, text.Start+k, text.RuneStart+runes
+	return text, emote, zeroWidth
+}
+
+// Returns a token covering two adjacent tokens, of the kind of the first.
+func joinTokens(a Token, b Token) Token {
+	a.Text += b.Text
+	a.End, a.RuneEnd = b.End, b.RuneEnd
+	return a
+}
@@ -0,0 +1,122 @@
+package emodl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompose(t *testing.T) {
+	t.Parallel()
+
+	m := EmoteMap{
+		"catJAM":   {Name: "catJAM"},
+		"Kappa":    {Name: "Kappa"},
+		"RainTime": {Name: "RainTime", ZeroWidth: true},
+		"ffzW":     {Name: "ffzW", Modifier: true, Transform: TransformWide},
+		"ffzX":     {Name: "ffzX", Modifier: true, ZeroWidth: true, Transform: TransformFlipX},
+	}
+
+	type group struct {
+		text      string
+		overlays  []string
+		transform EmoteTransform
+	}
+	for _, tc := range []struct {
+		message string
+		want    []group
+	}{
+		{"catJAM RainTime", []group{{"catJAM RainTime", []string{"RainTime"}, 0}}},
+		{"catJAM ffzW lol", []group{{"catJAM ffzW", nil, TransformWide}, {" lol", nil, 0}}},
+		{"catJAM  ffzX RainTime", []group{{"catJAM  ffzX RainTime", []string{"ffzX", "RainTime"}, TransformFlipX}}},
+		{"hi w! h! catJAM", []group{{"hi ", nil, 0}, {"w! h! catJAM", nil, TransformWide | TransformFlipX}}},
+		{"Kappa v! z! catJAM", []group{{"Kappa v! z! catJAM", []string{"v! z! catJAM"}, 0}}},
+		{"hi z! catJAM", []group{{"hi ", nil, 0}, {"z! catJAM", nil, 0}}},
+		{"RainTime catJAM", []group{{"RainTime", nil, 0}, {" ", nil, 0}, {"catJAM", nil, 0}}},
+		{"catJAM hi RainTime", []group{{"catJAM", nil, 0}, {" hi ", nil, 0}, {"RainTime", nil, 0}}},
+		{"w! h!", []group{{"w! h!", nil, 0}}},
+	} {
+		tokens := m.Compose(tc.message)
+
+		var joined strings.Builder
+		for _, tok := range tokens {
+			joined.WriteString(tok.Text)
+			if tc.message[tok.Start:tok.End] != tok.Text {
+				t.Errorf("%q: byte offsets %d-%d do not cover %q", tc.message, tok.Start, tok.End, tok.Text)
+			}
+		}
+		if joined.String() != tc.message {
+			t.Errorf("%q: tokens join to %q", tc.message, joined.String())
+		}
+
+		if len(tokens) != len(tc.want) {
+			t.Errorf("%q: expected %d tokens, got %+v", tc.message, len(tc.want), tokens)
+			continue
+		}
+		for i, want := range tc.want {
+			tok := tokens[i]
+			var overlays []string
+			for _, o := range tok.Overlays {
+				overlays = append(overlays, o.Text)
+			}
+			if tok.Text != want.text || strings.Join(overlays, ",") != strings.Join(want.overlays, ",") || tok.Transform != want.transform {
+				t.Errorf("%q: token %d: expected %q over %v transform %d, got %q over %v transform %d",
+					tc.message, i, want.text, want.overlays, want.transform, tok.Text, overlays, tok.Transform)
+			}
+		}
+	}
+}
+
+func TestDownloaderCompose(t *testing.T) {
+	t.Parallel()
+	ed := NewDownloader(fakeChannelOptions(newFakeServer(t)))
+	if _, err := ed.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 7TV zero-width emote over an FFZ emote flipped by a hidden FFZ
+	// modifier
+	tokens := ed.Compose("monkaS ffzY RainTime")
+	if len(tokens) != 1 {
+		t.Fatalf("Expected a single group, got %+v", tokens)
+	}
+	if tokens[0].Emote.Provider != ProviderFFZ || tokens[0].Transform != TransformFlipY ||
+		len(tokens[0].Overlays) != 1 || tokens[0].Overlays[0].Emote.Name != "RainTime" {
+		t.Errorf("Unexpected group %+v", tokens[0])
+	}
+
+	// ffzW is not hidden, so it is drawn over the widened emote
+	tokens = ed.Compose("EZ ffzW")
+	if len(tokens) != 1 || tokens[0].Transform != TransformWide ||
+		len(tokens[0].Overlays) != 1 || tokens[0].Overlays[0].Emote.Name != "ffzW" {
+		t.Errorf("Unexpected group %+v", tokens)
+	}
+
+	// ffzX is hidden with no transform of its own
+	tokens = ed.Compose("EZ ffzX")
+	if len(tokens) != 1 || tokens[0].Transform != 0 || len(tokens[0].Overlays) != 0 {
+		t.Errorf("Unexpected group %+v", tokens)
+	}
+}
+
+func TestSevenTVActiveEmoteZeroWidth(t *testing.T) {
+	t.Parallel()
+
+	var set SevenTVEmoteSet
+	err := set.UnmarshalJSON([]byte(`{"name":"test","emotes":[
+		{"name":"overlay","flags":1,"data":{"id":"1","name":"Overlay","flags":0,"host":{"url":"//cdn.7tv.app/emote/1","files":[{"name":"1x.webp","format":"WEBP"}]}}},
+		{"name":"normal","flags":0,"data":{"id":"2","name":"normal","flags":0,"host":{"url":"//cdn.7tv.app/emote/2","files":[{"name":"1x.webp","format":"WEBP"}]}}}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []bool{true, false} {
+		se := set.providerEmotes()[i].(SevenTVEmote)
+		e, err := se.AsEmote()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.ZeroWidth != want {
+			t.Errorf("%s: expected zero-width %t", e.Name, want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package emodl
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ConflictEmote is one of the emotes sharing a name.
+type ConflictEmote struct {
+	Provider string `json:"provider"`
+	ID       string `json:"id"`
+	Scope    string `json:"scope"`
+}
+
+// Conflict is an emote name shared by several loaded emotes. Only the
+// winner is in the map returned by Load; the others are shadowed by it.
+type Conflict struct {
+	Name   string        `json:"name"`
+	Winner ConflictEmote `json:"winner"`
+
+	// Highest precedence first
+	Shadowed []ConflictEmote `json:"shadowed"`
+}
+
+//easyjson:json
+type ConflictSlice []Conflict
+
+// Conflicts returns every emote name shared by several loaded emotes,
+// sorted by name. Marshal the result to get a JSON report.
+func (ed *Downloader) Conflicts() ConflictSlice {
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+
+	var conflicts ConflictSlice
+	for name, cs := range ed.candidates {
+		if len(cs) < 2 {
+			continue
+		}
+		c := Conflict{
+			Name:     name,
+			Winner:   cs[0].conflictEmote(),
+			Shadowed: make([]ConflictEmote, 0, len(cs)-1),
+		}
+		for _, s := range cs[1:] {
+			c.Shadowed = append(c.Shadowed, s.conflictEmote())
+		}
+		conflicts = append(conflicts, c)
+	}
+	slices.SortFunc(conflicts, func(a, b Conflict) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return conflicts
+}
+
+func (c candidate) conflictEmote() ConflictEmote {
+	return ConflictEmote{
+		Provider: c.emote.Provider,
+		ID:       c.emote.ID,
+		Scope:    c.emote.Scope,
+	}
+}
+
+// String formats an emote as [Provider scope].
+func (e ConflictEmote) String() string {
+	return fmt.Sprintf("[%s %s]", e.Provider, e.Scope)
+}
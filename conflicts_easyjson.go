@@ -0,0 +1,229 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package emodl
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonA8a7b98bDecodeGithubComJdavasligilEmodl(in *jlexer.Lexer, out *ConflictSlice) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(ConflictSlice, 0, 0)
+			} else {
+				*out = ConflictSlice{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 Conflict
+			easyjsonA8a7b98bDecodeGithubComJdavasligilEmodl1(in, &v1)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA8a7b98bEncodeGithubComJdavasligilEmodl(out *jwriter.Writer, in ConflictSlice) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			easyjsonA8a7b98bEncodeGithubComJdavasligilEmodl1(out, v3)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ConflictSlice) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA8a7b98bEncodeGithubComJdavasligilEmodl(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ConflictSlice) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA8a7b98bEncodeGithubComJdavasligilEmodl(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ConflictSlice) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA8a7b98bDecodeGithubComJdavasligilEmodl(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ConflictSlice) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA8a7b98bDecodeGithubComJdavasligilEmodl(l, v)
+}
+func easyjsonA8a7b98bDecodeGithubComJdavasligilEmodl1(in *jlexer.Lexer, out *Conflict) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "winner":
+			easyjsonA8a7b98bDecodeGithubComJdavasligilEmodl2(in, &out.Winner)
+		case "shadowed":
+			if in.IsNull() {
+				in.Skip()
+				out.Shadowed = nil
+			} else {
+				in.Delim('[')
+				if out.Shadowed == nil {
+					if !in.IsDelim(']') {
+						out.Shadowed = make([]ConflictEmote, 0, 1)
+					} else {
+						out.Shadowed = []ConflictEmote{}
+					}
+				} else {
+					out.Shadowed = (out.Shadowed)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 ConflictEmote
+					easyjsonA8a7b98bDecodeGithubComJdavasligilEmodl2(in, &v4)
+					out.Shadowed = append(out.Shadowed, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA8a7b98bEncodeGithubComJdavasligilEmodl1(out *jwriter.Writer, in Conflict) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"winner\":"
+		out.RawString(prefix)
+		easyjsonA8a7b98bEncodeGithubComJdavasligilEmodl2(out, in.Winner)
+	}
+	{
+		const prefix string = ",\"shadowed\":"
+		out.RawString(prefix)
+		if in.Shadowed == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Shadowed {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonA8a7b98bEncodeGithubComJdavasligilEmodl2(out, v6)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonA8a7b98bDecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *ConflictEmote) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "provider":
+			out.Provider = string(in.String())
+		case "id":
+			out.ID = string(in.String())
+		case "scope":
+			out.Scope = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA8a7b98bEncodeGithubComJdavasligilEmodl2(out *jwriter.Writer, in ConflictEmote) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"provider\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Provider))
+	}
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix)
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"scope\":"
+		out.RawString(prefix)
+		out.String(string(in.Scope))
+	}
+	out.RawByte('}')
+}
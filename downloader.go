@@ -1,8 +1,5 @@
 // TODO:
-// - Get URL from Name
 // - Get channel custom emotes
-// - Get custom badges
-// - Option: Periodic update checks? (go CheckUpdates)
 
 // Reference:
 // https://github.com/SevenTV/chatterino7/blob/chatterino7/src/providers/seventv/SeventvAPI.cpp
@@ -10,9 +7,12 @@
 package emodl
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"maps"
+	"net/http"
+	"slices"
 	"strings"
 	"sync"
 	"text/template"
@@ -39,13 +39,47 @@ type Image struct {
 	Width  int    `json:"width"`
 	Height int    `json:"height"`
 	ID     string `json:"id"`
+
+	// Scale ("1x", "2x", etc.), format ("PNG", "GIF", etc.) and theme
+	// ("light" or "dark") of the image, if known
+	Scale  string `json:"scale,omitempty"`
+	Format string `json:"format,omitempty"`
+	Theme  string `json:"theme,omitempty"`
 }
 
 type Emote struct {
-	ID        string   `json:"id"`
-	Name      string   `json:"name"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+
+	// ScopeGlobal, ScopeChannel or ScopeShared
+	Scope string `json:"scope"`
+
+	Animated bool `json:"animated"`
+
+	// Zero-width emotes are drawn over the emote before them
+	ZeroWidth bool `json:"zero_width"`
+
+	// Modifier emotes apply Transform to the emote before them
+	Modifier  bool           `json:"modifier"`
+	Transform EmoteTransform `json:"transform"`
+
+	// User that uploaded the emote, if known
+	Owner *EmoteOwner `json:"owner,omitempty"`
+
+	// Occurrences of the emote in a message as "start-end" rune offsets,
+	// filled in by Tokenize
 	Locations []string `json:"locations"`
-	Images    []Image  `json:"images"`
+
+	Images []Image `json:"images"`
+}
+
+// EmoteOwner is a provider user. Fields the provider does not give are
+// empty.
+type EmoteOwner struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
 }
 
 type emotePath struct {
@@ -58,259 +92,328 @@ type DownloaderOptions struct {
 	BTTV    *BTTVOptions
 	SevenTV *SevenTVOptions
 	FFZ     *FFZOptions
+
+	// Native Twitch emotes need credentials, so they are only loaded if set
+	Twitch *TwitchOptions
+
+	// HTTP client used for every request (http.DefaultClient if nil)
+	Client *http.Client
+
+	// Base URL (scheme + host) of each provider API indexed by provider
+	// name, e.g. "https://api.betterttv.net". Missing providers use the
+	// public APIs.
+	BaseURLs map[string]string
+
+	// User-Agent header sent with every request (Go default if empty)
+	UserAgent string
+
+	// Maximum number of images downloaded at once (8 if zero)
+	ImageFetches int
+
+	// Persistent cache of API responses and images (disabled if nil)
+	Cache *DiskCache
+
+	// Read the size of BTTV images from their headers on Load, since BTTV
+	// does not report it. See ImageFetcher.Probe.
+	ProbeImages bool
+
+	// Which emote wins when emotes share a name
+	Precedence Precedence
+
+	// Options of the Matcher built on every Load
+	Matcher MatcherOptions
 }
 
 // Downloads and caches third party emote data as maps indexed by name.
+//
+// Every Load and live update changes the emote maps in place. While Watch
+// or a live client is running, hold RLock to read them.
 type Downloader struct {
 	Options       DownloaderOptions
 	BTTVEmotes    map[string]BTTVEmote
 	FFZEmotes     map[string]FFZEmote
 	SevenTVEmotes map[string]SevenTVEmote
+	TwitchEmotes  map[string]TwitchEmote
+
+	providers []Provider
+	images    *ImageFetcher
+
+	mu         *sync.RWMutex
+	sources    map[sourceKey]source
+	emotes     map[string]Emote
+	candidates map[string][]candidate
+	matcher    *Matcher
+
+	badgeSources map[string][]Badge
+	badges       map[string][]Badge
+	paintSources map[string][]Paint
+	paints       map[string]Paint
+}
+
+// Identifies the global or channel request of a provider.
+type sourceKey struct {
+	provider string
+	channel  bool
+}
+
+// The emotes of the last successful request of a source. Native and
+// converted emotes share indices.
+type source struct {
+	native []ProviderEmote
+	emotes []Emote
 }
 
 func NewDownloader(opt DownloaderOptions) Downloader {
-	ed := Downloader{Options: opt}
+	ed := Downloader{
+		Options:   opt,
+		providers: newProviders(opt),
+		images:    NewImageFetcher(opt.Client, opt.UserAgent, opt.ImageFetches),
+		mu:        &sync.RWMutex{},
+		sources:   make(map[sourceKey]source, 8),
+		emotes:    make(map[string]Emote, 256),
+
+		candidates: make(map[string][]candidate, 256),
+		matcher:    NewMatcher(nil, opt.Matcher),
+
+		badgeSources: make(map[string][]Badge, 4),
+		badges:       make(map[string][]Badge, 256),
+		paintSources: make(map[string][]Paint, 1),
+		paints:       make(map[string]Paint, 256),
+	}
+	ed.images.cache = opt.Cache
 	ed.BTTVEmotes = make(map[string]BTTVEmote, 64)
 	ed.FFZEmotes = make(map[string]FFZEmote, 64)
 	ed.SevenTVEmotes = make(map[string]SevenTVEmote, 64)
+	ed.TwitchEmotes = make(map[string]TwitchEmote, 64)
 	return ed
 }
 
 // Loads all emote and badge data into memory based on configuration.
-// Returns a map of emotes indexed by name.
+// Returns a copy of the map of emotes indexed by name, which later loads
+// and live updates do not change.
+//
+// Downloading is bounded by a timeout of downloadTimeout seconds.
 func (ed *Downloader) Load() (map[string]Emote, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout*time.Second)
+	defer cancel()
+	return ed.LoadContext(ctx)
+}
+
+// LoadContext is like Load but cancellation and deadlines of ctx apply to
+// every request in flight. The emotes loaded before ctx is done are
+// returned along with the context error.
+//
+// If a request fails, the emotes of its last successful load are kept.
+func (ed *Downloader) LoadContext(ctx context.Context) (map[string]Emote, error) {
 	if ed == nil {
 		return nil, errors.New("Nil dereference on Downloader")
 	}
 	var err error
 
-	emotes := make(map[string]Emote, 256)
-
-	errorChan := make(chan error, 8)
-	sevenTVEmotesChan := make(chan SevenTVEmoteSet, 8)
-	bttvEmotesChan := make(chan BTTVEmoteSlice, 8)
-	ffzEmotesChan := make(chan []FFZEmote, 8)
-
-	wgdone := make(chan struct{})
-	done := make(chan struct{})
-
-	// Copier goroutine will copy emote data into the map as it comes in
-	go func() {
-		for {
-			select {
-			case set := <-sevenTVEmotesChan:
-				for _, data := range set.Emotes {
-					e := data.Data
-					e.Name = data.Name
-					ed.SevenTVEmotes[e.Name] = e
-					emotes[e.Name], err = e.AsEmote()
-					if err != nil {
-						errorChan <- err
-					}
-				}
-			case es := <-bttvEmotesChan:
-				for _, e := range es {
-					ed.BTTVEmotes[e.Name] = e
-					emotes[e.Name] = e.AsEmote()
-				}
-			case es := <-ffzEmotesChan:
-				for _, e := range es {
-					ed.FFZEmotes[e.Name] = e
-					emotes[e.Name] = e.AsEmote()
-				}
-			case e := <-errorChan:
-				err = errors.Join(e)
-			case <-wgdone:
-				// Collect all buffered errors and emotes when done downloading
-				close(sevenTVEmotesChan)
-				for s := range sevenTVEmotesChan {
-					for _, data := range s.Emotes {
-						e := data.Data
-						e.Name = data.Name
-						ed.SevenTVEmotes[e.Name] = e
-						emotes[e.Name], err = e.AsEmote()
-						if err != nil {
-							errorChan <- err
-						}
-					}
-				}
-				close(bttvEmotesChan)
-				for es := range bttvEmotesChan {
-					for _, e := range es {
-						ed.BTTVEmotes[e.Name] = e
-						emotes[e.Name] = e.AsEmote()
-					}
-				}
-				close(ffzEmotesChan)
-				for es := range ffzEmotesChan {
-					for _, e := range es {
-						ed.FFZEmotes[e.Name] = e
-						emotes[e.Name] = e.AsEmote()
-					}
-				}
-				close(errorChan)
-				for e := range errorChan {
-					err = errors.Join(e)
-				}
-				done <- struct{}{}
-				return
-			}
-		}
-	}()
+	// Buffered so that no request routine blocks if loading is cancelled
+	resultChan := make(chan providerResult, 2*len(ed.providers))
 
 	// Get request routines will download emote data asynchronously
-	var wg sync.WaitGroup
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		bttvEmotes, err := getBTTVGlobalEmotes()
-		if err != nil {
-			errorChan <- errors.New(fmt.Sprintf("emodl: %v: failure getting global BTTV emotes", err))
-			return
-		}
-		bttvEmotesChan <- bttvEmotes
-	}()
-
-	if ed.Options.BTTV != nil {
-		wg.Add(1)
+	for _, p := range ed.providers {
 		go func() {
-			defer wg.Done()
-
-			bttvEmotes, err := getBTTVUserEmotes(ed.Options.BTTV.Platform, ed.Options.BTTV.PlatformID)
+			es, err := p.GlobalEmotes(ctx)
 			if err != nil {
-				errorChan <- errors.New(fmt.Sprintf("emodl: %v: failure getting BTTV user emotes", err))
-				return
+				err = fmt.Errorf("emodl: %v: failure getting global %s emotes", err, p.Name())
 			}
-			bttvEmotesChan <- bttvEmotes
+			resultChan <- providerResult{provider: p, emotes: es, err: err}
+		}()
+		go func() {
+			es, err := p.ChannelEmotes(ctx)
+			if err != nil {
+				err = fmt.Errorf("emodl: %v: failure getting channel %s emotes", err, p.Name())
+			}
+			resultChan <- providerResult{provider: p, channel: true, emotes: es, err: err}
 		}()
 	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		s, err := get7TVEmoteSet("global")
-		if err != nil {
-			errorChan <- errors.New(fmt.Sprintf("emodl: %v: failure getting global 7TV emotes", err))
-			return
+	results := make([]providerResult, 0, 2*len(ed.providers))
+collect:
+	for range 2 * len(ed.providers) {
+		select {
+		case r := <-resultChan:
+			results = append(results, r)
+		case <-ctx.Done():
+			err = ctx.Err()
+			break collect
 		}
-		sevenTVEmotesChan <- s
-	}()
+	}
 
-	if ed.Options.SevenTV != nil {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
+	// Converted before locking since probing downloads images
+	sources := make([]source, len(results))
+	for i, r := range results {
+		if r.err != nil {
+			continue
+		}
+		s, convErr := r.source()
+		err = errors.Join(err, convErr)
+		if ed.Options.ProbeImages && r.provider.Name() == ProviderBTTV {
+			err = errors.Join(err, ed.images.probeEmotes(ctx, s.emotes))
+		}
+		sources[i] = s
+	}
 
-			sids, err := get7TVUserEmoteSetIDs(ed.Options.SevenTV.Platform, ed.Options.SevenTV.PlatformID)
-			if err != nil {
-				errorChan <- errors.New(fmt.Sprintf("emodl: %v: failure getting user 7TV emotes with opt %v", err, *ed.Options.SevenTV))
-				return
-			}
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
 
-			for _, sid := range sids {
-				wg.Add(1)
-				go func() {
-					defer wg.Done()
-					s, err := get7TVEmoteSet(sid)
-					if err != nil {
-						errorChan <- errors.New(fmt.Sprintf("emodl: %v: failure getting 7TV emote set %s", err, sid))
-						return
-					}
-					sevenTVEmotesChan <- s
-				}()
-			}
-		}()
+	for i, r := range results {
+		if r.err != nil {
+			err = errors.Join(err, r.err)
+			continue
+		}
+		ed.sources[sourceKey{provider: r.provider.Name(), channel: r.channel}] = sources[i]
 	}
+	ed.merge()
+
+	return maps.Clone(ed.emotes), err
+}
+
+// RLock locks the provider emote maps of the Downloader, such as
+// BTTVEmotes, for reading. Only needed while they may be updated
+// concurrently, e.g. by Watch.
+func (ed *Downloader) RLock() {
+	ed.mu.RLock()
+}
+
+// RUnlock undoes a single RLock call.
+func (ed *Downloader) RUnlock() {
+	ed.mu.RUnlock()
+}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		sets, err := getFFZEmoteSets("global")
+// Emotes returns a copy of the map of emotes by name of the last Load or
+// live update. It takes its own lock, so it must not be called under
+// RLock.
+func (ed *Downloader) Emotes() map[string]Emote {
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+	return maps.Clone(ed.emotes)
+}
+
+// Images returns the ImageFetcher used to download emote images with the
+// HTTP client and User-Agent of the Downloader.
+func (ed *Downloader) Images() *ImageFetcher {
+	return ed.images
+}
+
+type providerResult struct {
+	provider Provider
+	channel  bool
+	emotes   []ProviderEmote
+	err      error
+}
+
+// Converts the emotes of a provider result. Emotes that fail to convert
+// are left out.
+func (r providerResult) source() (source, error) {
+	var errs []error
+	s := source{
+		native: make([]ProviderEmote, 0, len(r.emotes)),
+		emotes: make([]Emote, 0, len(r.emotes)),
+	}
+	for _, pe := range r.emotes {
+		e, err := r.provider.AsEmote(pe)
 		if err != nil {
-			errorChan <- errors.New(fmt.Sprintf("emodl: %s", err))
-			return
-		}
-		for _, set := range sets {
-			ffzEmotesChan <- set.Emotes
+			errs = append(errs, fmt.Errorf("emodl: %v: failure converting %s emote", err, r.provider.Name()))
+			continue
 		}
-	}()
-
-	if ed.Options.FFZ != nil {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			set, err := getFFZRoomEmoteSet(ed.Options.FFZ.Platform, ed.Options.FFZ.PlatformID)
-			if err != nil {
-				errorChan <- errors.New(fmt.Sprintf("emodl: %s", err))
-				return
+		e.Provider = r.provider.Name()
+		if e.Scope == "" {
+			e.Scope = ScopeGlobal
+			if r.channel {
+				e.Scope = ScopeChannel
 			}
-			ffzEmotesChan <- set.Emotes
-		}()
+		}
+		s.native = append(s.native, pe)
+		s.emotes = append(s.emotes, e)
 	}
+	return s, errors.Join(errs...)
+}
 
-	wg.Wait()
-	wgdone <- struct{}{}
+// An emote of a source along with its native representation.
+type candidate struct {
+	native ProviderEmote
+	emote  Emote
+}
+
+// Rebuilds the emote maps in place from the sources of every provider.
+// Emotes of the built in providers are also kept in their provider specific
+// map.
+//
+// Sources are visited in the order of the Precedence option, later emotes
+// of a source first. The first emote of a name wins unless overridden.
+func (ed *Downloader) merge() {
+	if ed.BTTVEmotes == nil {
+		ed.BTTVEmotes = make(map[string]BTTVEmote, 64)
+	}
+	if ed.FFZEmotes == nil {
+		ed.FFZEmotes = make(map[string]FFZEmote, 64)
+	}
+	if ed.SevenTVEmotes == nil {
+		ed.SevenTVEmotes = make(map[string]SevenTVEmote, 64)
+	}
+	if ed.TwitchEmotes == nil {
+		ed.TwitchEmotes = make(map[string]TwitchEmote, 64)
+	}
+	clear(ed.BTTVEmotes)
+	clear(ed.FFZEmotes)
+	clear(ed.SevenTVEmotes)
+	clear(ed.TwitchEmotes)
+	clear(ed.emotes)
+	clear(ed.candidates)
+
+	precedence := ed.Options.Precedence
+	for _, key := range precedence.sourceOrder(ed.providers) {
+		s := ed.sources[key]
+		for i, pe := range slices.Backward(s.native) {
+			e := s.emotes[i]
+			ed.candidates[e.Name] = append(ed.candidates[e.Name], candidate{native: pe, emote: e})
+		}
+	}
 
-	timeout := time.NewTicker(downloadTimeout * time.Second)
-	select {
-	case <-done:
-	case <-timeout.C:
-		log.Printf("Timeout after %d seconds.\n", downloadTimeout)
+	for name, cs := range ed.candidates {
+		precedence.override(name, cs)
+		ed.emotes[name] = cs[0].emote
+		for _, c := range cs {
+			switch v := c.native.(type) {
+			case BTTVEmote:
+				setFirst(ed.BTTVEmotes, name, v)
+			case FFZEmote:
+				setFirst(ed.FFZEmotes, name, v)
+			case SevenTVEmote:
+				setFirst(ed.SevenTVEmotes, name, v)
+			case TwitchEmote:
+				setFirst(ed.TwitchEmotes, name, v)
+			}
+		}
 	}
+	ed.matcher = NewMatcher(ed.emotes, ed.Options.Matcher)
+}
 
-	return emotes, err
+func setFirst[T any](m map[string]T, name string, v T) {
+	if _, ok := m[name]; !ok {
+		m[name] = v
+	}
 }
 
-// Generate a formatted string reporting emote name conflicts.
+// Generate a formatted string reporting emote name conflicts, with a line
+// for every emote shadowed by the winner of its name:
+//
+//	[FFZ channel] [7TV channel] -> monkaS
+//
+// The emotes argument is ignored; conflicts are those of the last Load.
 func (ed *Downloader) ReportConflicts(emotes map[string]Emote) string {
 	var sb strings.Builder
 	var count int
 
 	sb.WriteString("Emote Conflicts:\n")
-
-	if len(emotes) != (len(ed.BTTVEmotes) + len(ed.SevenTVEmotes) + len(ed.FFZEmotes)) {
-		if len(ed.BTTVEmotes) < len(ed.SevenTVEmotes) && len(ed.BTTVEmotes) < len(ed.FFZEmotes) {
-			for name := range ed.BTTVEmotes {
-				_, ok := ed.SevenTVEmotes[name]
-				if ok {
-					sb.WriteString(fmt.Sprintf("\t[BTTV] [7TV] -> %s\n", name))
-					count++
-				}
-				_, ok = ed.FFZEmotes[name]
-				if ok {
-					sb.WriteString(fmt.Sprintf("\t[BTTV] [FFZ] -> %s\n", name))
-					count++
-				}
-			}
-		} else if len(ed.SevenTVEmotes) < len(ed.BTTVEmotes) && len(ed.SevenTVEmotes) < len(ed.FFZEmotes) {
-			for name := range ed.SevenTVEmotes {
-				_, ok := ed.BTTVEmotes[name]
-				if ok {
-					sb.WriteString(fmt.Sprintf("\t[7TV] [BTTV] -> %s\n", name))
-					count++
-				}
-				_, ok = ed.FFZEmotes[name]
-				if ok {
-					sb.WriteString(fmt.Sprintf("\t[7TV] [FFZ] -> %s\n", name))
-					count++
-				}
-			}
-		} else if len(ed.FFZEmotes) < len(ed.SevenTVEmotes) && len(ed.FFZEmotes) < len(ed.BTTVEmotes) {
-			for name := range ed.FFZEmotes {
-				_, ok := ed.BTTVEmotes[name]
-				if ok {
-					sb.WriteString(fmt.Sprintf("\t[FFZ] [BTTV] -> %s\n", name))
-					count++
-				}
-				_, ok = ed.SevenTVEmotes[name]
-				if ok {
-					sb.WriteString(fmt.Sprintf("\t[FFZ] [7TV] -> %s\n", name))
-					count++
-				}
-			}
+	for _, c := range ed.Conflicts() {
+		for _, s := range c.Shadowed {
+			sb.WriteString(fmt.Sprintf("\t%s %s -> %s\n", c.Winner, s, c.Name))
+			count++
 		}
 	}
 	sb.WriteString(fmt.Sprintf("Total Conflicts: %d\n", count))
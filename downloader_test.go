@@ -1,28 +1,20 @@
 package emodl
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestDownloader(t *testing.T) {
 	t.Parallel()
 
-	ed := NewDownloader(DownloaderOptions{
-		BTTV: &BTTVOptions{
-			Platform:   "twitch",
-			PlatformID: "39226538",
-		},
-		SevenTV: &SevenTVOptions{
-			Platform:   "twitch",
-			PlatformID: "1048391821",
-		},
-		FFZ: &FFZOptions{
-			Platform:   "twitch",
-			PlatformID: "39226538",
-		},
-	})
+	ed := NewDownloader(fakeChannelOptions(newFakeServer(t)))
 
 	emotes, err := ed.Load()
 	if err != nil {
@@ -31,17 +23,34 @@ func TestDownloader(t *testing.T) {
 	if emotes == nil {
 		t.Fatal("Emotes map is nil")
 	}
-	if len(ed.BTTVEmotes) == 0 {
-		t.Fatal("BTTVEmotes is empty.")
+	if len(ed.BTTVEmotes) != 9 {
+		t.Fatalf("Expected 9 BTTVEmotes, got %d", len(ed.BTTVEmotes))
+	}
+	if len(ed.SevenTVEmotes) != 7 {
+		t.Fatalf("Expected 7 SevenTVEmotes, got %d", len(ed.SevenTVEmotes))
 	}
-	if len(ed.SevenTVEmotes) == 0 {
-		t.Fatal("SevenTVEmotes is empty")
+	if len(ed.FFZEmotes) != 9 {
+		t.Fatalf("Expected 9 FFZEmotes, got %d", len(ed.FFZEmotes))
 	}
-	if len(ed.FFZEmotes) == 0 {
-		t.Fatal("FFZEmotes is empty")
+	if len(emotes) != 22 {
+		t.Fatalf("Expected 22 merged emotes, got %d", len(emotes))
+	}
+	if _, ok := ed.SevenTVEmotes["peepoHappy"]; !ok {
+		t.Fatal("7TV emote not stored under its name in the set")
 	}
 
-	t.Log(ed.ReportConflicts(emotes))
+	report := ed.ReportConflicts(emotes)
+	for _, line := range []string{
+		"\t[BTTV shared] [7TV global] -> catJAM\n",
+		"\t[FFZ channel] [7TV channel] -> monkaS\n",
+		"\t[FFZ channel] [BTTV channel] -> monkaS\n",
+		"Total Conflicts: 3\n",
+	} {
+		if !strings.Contains(report, line) {
+			t.Errorf("Report missing %q:\n%s", line, report)
+		}
+	}
+	t.Log(report)
 
 	var sb strings.Builder
 	sb.WriteString("\nDownloaded:\n")
@@ -56,3 +65,218 @@ func TestDownloader(t *testing.T) {
 	//}
 	//t.Log(s)
 }
+
+func TestDownloaderConflicts(t *testing.T) {
+	t.Parallel()
+	ed := NewDownloader(fakeChannelOptions(newFakeServer(t)))
+	if _, err := ed.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	conflicts := ed.Conflicts()
+	if len(conflicts) != 2 || conflicts[0].Name != "catJAM" || conflicts[1].Name != "monkaS" {
+		t.Fatalf("Expected conflicts of catJAM and monkaS, got %+v", conflicts)
+	}
+	monkaS := conflicts[1]
+	if monkaS.Winner != (ConflictEmote{Provider: ProviderFFZ, ID: "128054", Scope: ScopeChannel}) {
+		t.Errorf("Unexpected winner %+v", monkaS.Winner)
+	}
+	if len(monkaS.Shadowed) != 2 || monkaS.Shadowed[0].Provider != ProviderSevenTV || monkaS.Shadowed[1].Provider != ProviderBTTV {
+		t.Errorf("Expected 7TV and BTTV shadowed, got %+v", monkaS.Shadowed)
+	}
+
+	b, err := conflicts.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"catJAM","winner":{"provider":"BTTV","id":"5f1b0186cf6d2144653d2970","scope":"shared"},` +
+		`"shadowed":[{"provider":"7TV","id":"61e96c4e5f2b4e6a5a3f2c1d","scope":"global"}]}`
+	if !strings.HasPrefix(string(b), "["+want+",") {
+		t.Errorf("Unexpected JSON %s", b)
+	}
+}
+
+func TestDownloaderEmoteFields(t *testing.T) {
+	t.Parallel()
+	ed := NewDownloader(fakeChannelOptions(newFakeServer(t)))
+	emotes, err := ed.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]Emote{
+		"catJAM":      {Provider: ProviderBTTV, Scope: ScopeShared, Animated: true, Owner: &EmoteOwner{ID: "5c3e4a8d1f6a0b2c9d8e7f60", Name: "jeyhaw", DisplayName: "jeyhaw"}},
+		"cvHazmat":    {Provider: ProviderBTTV, Scope: ScopeGlobal, ZeroWidth: true, Owner: &EmoteOwner{ID: "5561169bd6b9d206222a8c19"}},
+		"RainTime":    {Provider: ProviderSevenTV, Scope: ScopeGlobal, Animated: true, ZeroWidth: true, Owner: &EmoteOwner{ID: "01GB2A2P8R000FKM3AHXY9CP1W", Name: "ayyybubu", DisplayName: "ayyybubu"}},
+		"EZ":          {Provider: ProviderSevenTV, Scope: ScopeGlobal, Owner: &EmoteOwner{ID: "01GB2A2P8R000FKM3AHXY9CP1W", Name: "ayyybubu", DisplayName: "ayyybubu"}},
+		"PartyParrot": {Provider: ProviderFFZ, Scope: ScopeChannel, Animated: true, Owner: &EmoteOwner{ID: "1", Name: "sirstendec", DisplayName: "SirStendec"}},
+		"ffzW":        {Provider: ProviderFFZ, Scope: ScopeGlobal, ZeroWidth: true, Owner: &EmoteOwner{ID: "1", Name: "sirstendec", DisplayName: "SirStendec"}},
+	} {
+		e, ok := emotes[name]
+		if !ok {
+			t.Errorf("%s: missing", name)
+			continue
+		}
+		if e.Provider != want.Provider || e.Scope != want.Scope || e.Animated != want.Animated || e.ZeroWidth != want.ZeroWidth {
+			t.Errorf("%s: expected %s %s animated %t zero-width %t, got %s %s animated %t zero-width %t", name,
+				want.Provider, want.Scope, want.Animated, want.ZeroWidth,
+				e.Provider, e.Scope, e.Animated, e.ZeroWidth)
+		}
+		if e.Owner == nil || *e.Owner != *want.Owner {
+			t.Errorf("%s: expected owner %+v, got %+v", name, want.Owner, e.Owner)
+		}
+	}
+}
+
+func TestDownloaderPrecedence(t *testing.T) {
+	t.Parallel()
+	srv := newFakeServer(t)
+
+	tests := []struct {
+		name       string
+		precedence Precedence
+		want       map[string]string
+	}{
+		{"Default", Precedence{}, map[string]string{
+			// Shared BTTV emote over 7TV global emote
+			"catJAM": ProviderBTTV + " " + ScopeShared,
+			"monkaS": ProviderFFZ + " " + ScopeChannel,
+		}},
+		{"Providers", Precedence{Providers: []string{ProviderBTTV, ProviderSevenTV}}, map[string]string{
+			"catJAM": ProviderBTTV + " " + ScopeShared,
+			"monkaS": ProviderBTTV + " " + ScopeChannel,
+		}},
+		{"ProviderFirst", Precedence{ProviderFirst: true}, map[string]string{
+			"catJAM": ProviderSevenTV + " " + ScopeGlobal,
+			"monkaS": ProviderFFZ + " " + ScopeChannel,
+		}},
+		{"Overrides", Precedence{Overrides: map[string]string{"monkaS": ProviderSevenTV, "catJAM": ProviderFFZ}}, map[string]string{
+			"catJAM": ProviderBTTV + " " + ScopeShared,
+			"monkaS": ProviderSevenTV + " " + ScopeChannel,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := fakeChannelOptions(srv)
+			opt.Precedence = tt.precedence
+			ed := NewDownloader(opt)
+
+			// Every load resolves conflicts the same way
+			for range 3 {
+				emotes, err := ed.Load()
+				if err != nil {
+					t.Fatal(err)
+				}
+				for name, want := range tt.want {
+					if got := emotes[name].Provider + " " + emotes[name].Scope; got != want {
+						t.Errorf("%s: expected %s, got %s", name, want, got)
+					}
+				}
+			}
+			if len(ed.SevenTVEmotes) != 7 || len(ed.BTTVEmotes) != 9 || len(ed.FFZEmotes) != 9 {
+				t.Error("Provider maps depend on precedence")
+			}
+		})
+	}
+}
+
+func TestDownloaderProviderError(t *testing.T) {
+	t.Parallel()
+
+	opt := fakeChannelOptions(newFakeServer(t))
+	opt.FFZ.PlatformID = "0"
+	ed := NewDownloader(opt)
+
+	emotes, err := ed.Load()
+	if err == nil || !strings.Contains(err.Error(), "failure getting channel FFZ emotes") {
+		t.Fatalf("Expected channel FFZ error, got %v", err)
+	}
+	if len(ed.FFZEmotes) != 6 {
+		t.Fatalf("Expected only the 6 global FFZEmotes, got %d", len(ed.FFZEmotes))
+	}
+	if _, ok := emotes["catJAM"]; !ok {
+		t.Fatal("Emotes of other providers missing after FFZ error")
+	}
+}
+
+func TestDownloaderLoadCopy(t *testing.T) {
+	t.Parallel()
+	ed := NewDownloader(fakeChannelOptions(newFakeServer(t)))
+
+	emotes, err := ed.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ed.applyPatch(ProviderSevenTV, true, sourcePatch{remove: []string{"6129ca7da4ab6a8c2a20d1f0"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := emotes["PETPET"]; !ok {
+		t.Error("Map returned by Load changed by a live update")
+	}
+	if _, ok := ed.Emotes()["PETPET"]; ok {
+		t.Error("Expected PETPET removed from Emotes")
+	}
+
+	again, err := ed.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	delete(again, "PETPET")
+	if _, ok := ed.Emotes()["PETPET"]; !ok {
+		t.Error("Map returned by Load shared with the Downloader")
+	}
+	delete(ed.Emotes(), "PETPET")
+	if _, ok := ed.Emotes()["PETPET"]; !ok {
+		t.Error("Map returned by Emotes shared with the Downloader")
+	}
+
+	// Emotes is safe to call while the emotes are updated
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 50 {
+			ed.Emotes()
+		}
+	}()
+	for range 50 {
+		if _, err := ed.applyPatch(ProviderSevenTV, true, sourcePatch{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-done
+}
+
+func TestDownloaderLoadContextCancel(t *testing.T) {
+	t.Parallel()
+
+	// Every request hangs until the client gives up
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ed := NewDownloader(DownloaderOptions{
+		SevenTV: &SevenTVOptions{
+			Platform:   "twitch",
+			PlatformID: "1048391821",
+		},
+		Client: srv.Client(),
+		BaseURLs: map[string]string{
+			ProviderBTTV:    srv.URL,
+			ProviderSevenTV: srv.URL,
+			ProviderFFZ:     srv.URL,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := ed.LoadContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("Load was not cancelled in time: %v", time.Since(start))
+	}
+}
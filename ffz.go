@@ -1,16 +1,14 @@
 package emodl
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"unsafe"
-
-	"github.com/mailru/easyjson"
 )
 
 // DOCUMENTATION
@@ -18,7 +16,6 @@ import (
 
 var (
 	ffzAPIVersion = "v1"
-	ffzHost       = "api.frankerfacez.com"
 	//ffzUserPathTmpl, _ = template.New("ffzUserPath").Parse("/{{ .Version }}/cached/users/{{ .Platform }}/{{ .PlatformID }}")
 )
 
@@ -36,6 +33,28 @@ type FFZEmote struct {
 	Height int               `json:"height"`
 	Width  int               `json:"width"`
 	URLs   map[string]string `json:"urls"`
+	// Animated images by scale, if the emote is animated
+	Animated map[string]string `json:"animated"`
+	// Modifier emotes change or are drawn over the emote before them
+	Modifier bool `json:"modifier"`
+	// Changes made by modifier emotes, see FFZModifierHidden
+	ModifierFlags int           `json:"modifier_flags"`
+	Owner         FFZEmoteOwner `json:"owner"`
+}
+
+// Modifier flags of FFZ emotes.
+const (
+	// The modifier is not drawn itself
+	FFZModifierHidden = 1 << iota
+	FFZModifierFlipX
+	FFZModifierFlipY
+	FFZModifierGrowX
+)
+
+type FFZEmoteOwner struct {
+	ID          int    `json:"_id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
 }
 
 type FFZEmoteSet struct {
@@ -87,13 +106,86 @@ func (e FFZEmote) Image(scale string) Image {
 	}
 }
 
+// AllImages returns an image of every scale of the emote, as PNG and as
+// WEBP if the emote is animated, sorted by scale.
+func (e FFZEmote) AllImages() []Image {
+	id := strconv.Itoa(e.ID)
+	imgs := make([]Image, 0, len(e.URLs)+len(e.Animated))
+	for _, urls := range []struct {
+		m      map[string]string
+		format string
+		path   string
+	}{{e.URLs, "PNG", "/"}, {e.Animated, "WEBP", "/animated/"}} {
+		for s, url := range urls.m {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				continue
+			}
+			imgs = append(imgs, Image{
+				URL:    url,
+				Width:  e.Width * n,
+				Height: e.Height * n,
+				ID:     id + urls.path + s,
+				Scale:  s + "x",
+				Format: urls.format,
+			})
+		}
+	}
+	slices.SortFunc(imgs, func(a, b Image) int {
+		return cmp.Or(cmp.Compare(parseScale(a.Scale), parseScale(b.Scale)), cmp.Compare(b.Format, a.Format))
+	})
+	return imgs
+}
+
+// GetImage returns the image of the emote nearest to a scale ("1x", "2x" or
+// "4x") and format. Every emote has PNG images, and animated emotes also
+// have WEBP images.
+func (e FFZEmote) GetImage(scale string, format string) (Image, error) {
+	img, ok := bestImage(e.AllImages(), scale, []string{format, "PNG"})
+	if !ok {
+		return Image{}, errors.New("FFZ Emote has no urls")
+	}
+	return img, nil
+}
+
 func (e FFZEmote) AsEmote() Emote {
-	return Emote{
+	em := Emote{
 		ID:        strconv.Itoa(e.ID),
 		Name:      e.Name,
-		Images:    []Image{e.Image("1")},
+		Provider:  ProviderFFZ,
+		Animated:  len(e.Animated) > 0,
+		ZeroWidth: e.Modifier && e.ModifierFlags&FFZModifierHidden == 0,
+		Modifier:  e.Modifier,
+		Transform: e.transform(),
+		Images:    e.AllImages(),
 		Locations: []string{},
 	}
+	if e.Owner.ID != 0 || e.Owner.Name != "" {
+		em.Owner = &EmoteOwner{
+			ID:          strconv.Itoa(e.Owner.ID),
+			Name:        e.Owner.Name,
+			DisplayName: e.Owner.DisplayName,
+		}
+	}
+	return em
+}
+
+// Returns the transform a modifier emote applies to the emote before it.
+func (e FFZEmote) transform() EmoteTransform {
+	var t EmoteTransform
+	if !e.Modifier {
+		return t
+	}
+	if e.ModifierFlags&FFZModifierFlipX != 0 {
+		t |= TransformFlipX
+	}
+	if e.ModifierFlags&FFZModifierFlipY != 0 {
+		t |= TransformFlipY
+	}
+	if e.ModifierFlags&FFZModifierGrowX != 0 {
+		t |= TransformWide
+	}
+	return t
 }
 
 func (e FFZEmote) Size() uintptr {
@@ -103,11 +195,14 @@ func (e FFZEmote) Size() uintptr {
 	for k, v := range e.URLs {
 		size += unsafe.Sizeof(k) + unsafe.Sizeof(v)
 	}
+	for k, v := range e.Animated {
+		size += unsafe.Sizeof(k) + unsafe.Sizeof(v)
+	}
 	return size
 }
 
 // Get User -> User Emote Sets -> User Emotes
-func getFFZRoomEmoteSet(platform string, platformID string) (FFZEmoteSet, error) {
+func getFFZRoomEmoteSet(ctx context.Context, c *apiClient, platform string, platformID string) (FFZEmoteSet, error) {
 	var ffzRoomData FFZRoomData
 	var ffzEmoteSet FFZEmoteSet
 	var path string
@@ -126,42 +221,8 @@ func getFFZRoomEmoteSet(platform string, platformID string) (FFZEmoteSet, error)
 	if err != nil {
 		return ffzEmoteSet, err
 	}
-	req := &http.Request{
-		Method: "GET",
-		URL: &url.URL{
-			Scheme: "https",
-			Host:   ffzHost,
-			Path:   sb.String(),
-		},
-		Header: http.Header{},
-	}
-
-	response, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return ffzEmoteSet, err
-	}
-	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		errorMessage := &jsonError{}
-		err = easyjson.UnmarshalFromReader(response.Body, errorMessage)
-		if err != nil {
-			body, err := io.ReadAll(response.Body)
-			if err != nil {
-				return ffzEmoteSet, err
-			}
-			return ffzEmoteSet, errors.New(response.Status + "\n" + string(body))
-		}
-		return ffzEmoteSet, errors.New(errorMessage.Error.Message)
-	}
-	// DEBUG
-	// body, err := io.ReadAll(response.Body)
-	// if err != nil {
-	// 	return ffzEmoteSet, err
-	// }
-	// fmt.Printf("BODY:\n%s\n", body)
-
-	err = easyjson.UnmarshalFromReader(response.Body, &ffzRoomData)
+	err = c.getJSON(ctx, sb.String(), &ffzRoomData)
 	if err != nil {
 		return ffzEmoteSet, err
 	}
@@ -179,7 +240,7 @@ func getFFZRoomEmoteSet(platform string, platformID string) (FFZEmoteSet, error)
 	return ffzEmoteSet, nil
 }
 
-func getFFZEmoteSets(setID string) ([]FFZEmoteSet, error) {
+func getFFZEmoteSets(ctx context.Context, c *apiClient, setID string) ([]FFZEmoteSet, error) {
 	var ffzEmoteSetResponse FFZEmoteSetResponse
 	var ffzEmoteSets []FFZEmoteSet
 	sb := strings.Builder{}
@@ -192,42 +253,7 @@ func getFFZEmoteSets(setID string) ([]FFZEmoteSet, error) {
 		return ffzEmoteSets, err
 	}
 
-	req := &http.Request{
-		Method: "GET",
-		URL: &url.URL{
-			Scheme: "https",
-			Host:   ffzHost,
-			Path:   sb.String(),
-		},
-		Header: http.Header{},
-	}
-
-	response, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return ffzEmoteSets, err
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		errorMessage := &jsonError{}
-		err = easyjson.UnmarshalFromReader(response.Body, errorMessage)
-		if err != nil {
-			body, err := io.ReadAll(response.Body)
-			if err != nil {
-				return ffzEmoteSets, err
-			}
-			return ffzEmoteSets, errors.New(response.Status + "\n" + string(body))
-		}
-		return ffzEmoteSets, errors.New(errorMessage.Error.Message)
-	}
-	// DEBUG
-	// body, err := io.ReadAll(response.Body)
-	// if err != nil {
-	// 	return ffzEmoteSets, err
-	// }
-	// fmt.Printf("BODY:\n%s\n", body)
-
-	err = easyjson.UnmarshalFromReader(response.Body, &ffzEmoteSetResponse)
+	err = c.getJSON(ctx, sb.String(), &ffzEmoteSetResponse)
 	if err != nil {
 		return ffzEmoteSets, err
 	}
@@ -246,3 +272,123 @@ func getFFZEmoteSets(setID string) ([]FFZEmoteSet, error) {
 
 	return ffzEmoteSets, nil
 }
+
+type FFZBadge struct {
+	ID    int               `json:"id"`
+	Name  string            `json:"name"`
+	Title string            `json:"title"`
+	Color string            `json:"color"`
+	URLs  map[string]string `json:"urls"`
+}
+
+//easyjson:json
+type FFZBadgeResponse struct {
+	Badges []FFZBadge `json:"badges"`
+	// Twitch user IDs indexed by badge ID
+	Users map[string][]int `json:"users"`
+}
+
+func getFFZBadges(ctx context.Context, c *apiClient) (FFZBadgeResponse, error) {
+	var badges FFZBadgeResponse
+	sb := strings.Builder{}
+	err := apiPathOptionTmpl.Execute(&sb, apiPath{
+		Version: ffzAPIVersion,
+		Path:    "badges",
+		Option:  "ids",
+	})
+	if err != nil {
+		return badges, err
+	}
+
+	err = c.getJSON(ctx, sb.String(), &badges)
+	if err != nil {
+		return badges, err
+	}
+	return badges, nil
+}
+
+type ffzProvider struct {
+	opt    *FFZOptions
+	client *apiClient
+}
+
+func newFFZProvider(opt DownloaderOptions) Provider {
+	return &ffzProvider{
+		opt:    opt.FFZ,
+		client: newAPIClient(opt, ProviderFFZ, ffzBaseURL),
+	}
+}
+
+func (p *ffzProvider) Name() string {
+	return ProviderFFZ
+}
+
+func (p *ffzProvider) GlobalEmotes(ctx context.Context) ([]ProviderEmote, error) {
+	sets, err := getFFZEmoteSets(ctx, p.client, "global")
+	if err != nil {
+		return nil, err
+	}
+	var pes []ProviderEmote
+	for _, set := range sets {
+		pes = append(pes, ffzProviderEmotes(set.Emotes)...)
+	}
+	return pes, nil
+}
+
+func (p *ffzProvider) ChannelEmotes(ctx context.Context) ([]ProviderEmote, error) {
+	if p.opt == nil {
+		return nil, nil
+	}
+	set, err := getFFZRoomEmoteSet(ctx, p.client, p.opt.Platform, p.opt.PlatformID)
+	if err != nil {
+		return nil, err
+	}
+	return ffzProviderEmotes(set.Emotes), nil
+}
+
+func (p *ffzProvider) AsEmote(e ProviderEmote) (Emote, error) {
+	fe, ok := e.(FFZEmote)
+	if !ok {
+		return Emote{}, fmt.Errorf("expected FFZEmote, got %T", e)
+	}
+	return fe.AsEmote(), nil
+}
+
+// FFZ badges are assigned to Twitch user IDs.
+func (p *ffzProvider) Badges(ctx context.Context) ([]Badge, error) {
+	r, err := getFFZBadges(ctx, p.client)
+	if err != nil {
+		return nil, err
+	}
+
+	badges := make([]Badge, 0, len(r.Badges))
+	for _, fb := range r.Badges {
+		id := strconv.Itoa(fb.ID)
+		b := Badge{
+			ID:       id,
+			Provider: ProviderFFZ,
+			Name:     fb.Name,
+			Title:    fb.Title,
+			Color:    fb.Color,
+		}
+		for _, scale := range []string{"1", "2", "4"} {
+			if url, ok := fb.URLs[scale]; ok {
+				n, _ := strconv.Atoi(scale)
+				b.Images = append(b.Images, badgeImage(id, url, n))
+			}
+		}
+		for _, u := range r.Users[id] {
+			b.Users = append(b.Users, strconv.Itoa(u))
+		}
+		badges = append(badges, b)
+	}
+	return badges, nil
+}
+
+func ffzProviderEmotes(es []FFZEmote) []ProviderEmote {
+	pes := make([]ProviderEmote, 0, len(es))
+	for _, e := range es {
+		pes = append(pes, e)
+	}
+	return pes
+}
@@ -149,7 +149,7 @@ func easyjson1d9e6730DecodeGithubComJdavasligilEmodl1(in *jlexer.Lexer, out *FFZ
 				in.Delim('[')
 				if out.Emotes == nil {
 					if !in.IsDelim(']') {
-						out.Emotes = make([]FFZEmote, 0, 1)
+						out.Emotes = make([]FFZEmote, 0, 0)
 					} else {
 						out.Emotes = []FFZEmote{}
 					}
@@ -244,6 +244,28 @@ func easyjson1d9e6730DecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *FFZ
 				}
 				in.Delim('}')
 			}
+		case "animated":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Animated = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v7 string
+					v7 = string(in.String())
+					(out.Animated)[key] = v7
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "modifier":
+			out.Modifier = bool(in.Bool())
+		case "modifier_flags":
+			out.ModifierFlags = int(in.Int())
+		case "owner":
+			easyjson1d9e6730DecodeGithubComJdavasligilEmodl3(in, &out.Owner)
 		default:
 			in.SkipRecursive()
 		}
@@ -285,20 +307,112 @@ func easyjson1d9e6730EncodeGithubComJdavasligilEmodl2(out *jwriter.Writer, in FF
 			out.RawString(`null`)
 		} else {
 			out.RawByte('{')
-			v7First := true
-			for v7Name, v7Value := range in.URLs {
-				if v7First {
-					v7First = false
+			v8First := true
+			for v8Name, v8Value := range in.URLs {
+				if v8First {
+					v8First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v8Name))
+				out.RawByte(':')
+				out.String(string(v8Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"animated\":"
+		out.RawString(prefix)
+		if in.Animated == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v9First := true
+			for v9Name, v9Value := range in.Animated {
+				if v9First {
+					v9First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v7Name))
+				out.String(string(v9Name))
 				out.RawByte(':')
-				out.String(string(v7Value))
+				out.String(string(v9Value))
 			}
 			out.RawByte('}')
 		}
 	}
+	{
+		const prefix string = ",\"modifier\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Modifier))
+	}
+	{
+		const prefix string = ",\"modifier_flags\":"
+		out.RawString(prefix)
+		out.Int(int(in.ModifierFlags))
+	}
+	{
+		const prefix string = ",\"owner\":"
+		out.RawString(prefix)
+		easyjson1d9e6730EncodeGithubComJdavasligilEmodl3(out, in.Owner)
+	}
+	out.RawByte('}')
+}
+func easyjson1d9e6730DecodeGithubComJdavasligilEmodl3(in *jlexer.Lexer, out *FFZEmoteOwner) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "_id":
+			out.ID = int(in.Int())
+		case "name":
+			out.Name = string(in.String())
+		case "display_name":
+			out.DisplayName = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1d9e6730EncodeGithubComJdavasligilEmodl3(out *jwriter.Writer, in FFZEmoteOwner) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"display_name\":"
+		out.RawString(prefix)
+		out.String(string(in.DisplayName))
+	}
 	out.RawByte('}')
 }
 func easyjson1d9e6730Decode(in *jlexer.Lexer, out *struct {
@@ -347,7 +461,7 @@ func easyjson1d9e6730Encode(out *jwriter.Writer, in struct {
 	}
 	out.RawByte('}')
 }
-func easyjson1d9e6730DecodeGithubComJdavasligilEmodl3(in *jlexer.Lexer, out *FFZEmoteSetResponse) {
+func easyjson1d9e6730DecodeGithubComJdavasligilEmodl4(in *jlexer.Lexer, out *FFZEmoteSetResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -382,9 +496,9 @@ func easyjson1d9e6730DecodeGithubComJdavasligilEmodl3(in *jlexer.Lexer, out *FFZ
 					out.DefaultSets = (out.DefaultSets)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v8 int
-					v8 = int(in.Int())
-					out.DefaultSets = append(out.DefaultSets, v8)
+					var v10 int
+					v10 = int(in.Int())
+					out.DefaultSets = append(out.DefaultSets, v10)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -398,9 +512,9 @@ func easyjson1d9e6730DecodeGithubComJdavasligilEmodl3(in *jlexer.Lexer, out *FFZ
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v9 FFZEmoteSet
-					easyjson1d9e6730DecodeGithubComJdavasligilEmodl1(in, &v9)
-					(out.Sets)[key] = v9
+					var v11 FFZEmoteSet
+					easyjson1d9e6730DecodeGithubComJdavasligilEmodl1(in, &v11)
+					(out.Sets)[key] = v11
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -415,7 +529,7 @@ func easyjson1d9e6730DecodeGithubComJdavasligilEmodl3(in *jlexer.Lexer, out *FFZ
 		in.Consumed()
 	}
 }
-func easyjson1d9e6730EncodeGithubComJdavasligilEmodl3(out *jwriter.Writer, in FFZEmoteSetResponse) {
+func easyjson1d9e6730EncodeGithubComJdavasligilEmodl4(out *jwriter.Writer, in FFZEmoteSetResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -426,11 +540,11 @@ func easyjson1d9e6730EncodeGithubComJdavasligilEmodl3(out *jwriter.Writer, in FF
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v10, v11 := range in.DefaultSets {
-				if v10 > 0 {
+			for v12, v13 := range in.DefaultSets {
+				if v12 > 0 {
 					out.RawByte(',')
 				}
-				out.Int(int(v11))
+				out.Int(int(v13))
 			}
 			out.RawByte(']')
 		}
@@ -442,16 +556,16 @@ func easyjson1d9e6730EncodeGithubComJdavasligilEmodl3(out *jwriter.Writer, in FF
 			out.RawString(`null`)
 		} else {
 			out.RawByte('{')
-			v12First := true
-			for v12Name, v12Value := range in.Sets {
-				if v12First {
-					v12First = false
+			v14First := true
+			for v14Name, v14Value := range in.Sets {
+				if v14First {
+					v14First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v12Name))
+				out.String(string(v14Name))
 				out.RawByte(':')
-				easyjson1d9e6730EncodeGithubComJdavasligilEmodl1(out, v12Value)
+				easyjson1d9e6730EncodeGithubComJdavasligilEmodl1(out, v14Value)
 			}
 			out.RawByte('}')
 		}
@@ -462,23 +576,290 @@ func easyjson1d9e6730EncodeGithubComJdavasligilEmodl3(out *jwriter.Writer, in FF
 // MarshalJSON supports json.Marshaler interface
 func (v FFZEmoteSetResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson1d9e6730EncodeGithubComJdavasligilEmodl3(&w, v)
+	easyjson1d9e6730EncodeGithubComJdavasligilEmodl4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v FFZEmoteSetResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson1d9e6730EncodeGithubComJdavasligilEmodl3(w, v)
+	easyjson1d9e6730EncodeGithubComJdavasligilEmodl4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *FFZEmoteSetResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson1d9e6730DecodeGithubComJdavasligilEmodl3(&r, v)
+	easyjson1d9e6730DecodeGithubComJdavasligilEmodl4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *FFZEmoteSetResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson1d9e6730DecodeGithubComJdavasligilEmodl3(l, v)
+	easyjson1d9e6730DecodeGithubComJdavasligilEmodl4(l, v)
+}
+func easyjson1d9e6730DecodeGithubComJdavasligilEmodl5(in *jlexer.Lexer, out *FFZBadgeResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "badges":
+			if in.IsNull() {
+				in.Skip()
+				out.Badges = nil
+			} else {
+				in.Delim('[')
+				if out.Badges == nil {
+					if !in.IsDelim(']') {
+						out.Badges = make([]FFZBadge, 0, 1)
+					} else {
+						out.Badges = []FFZBadge{}
+					}
+				} else {
+					out.Badges = (out.Badges)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v15 FFZBadge
+					easyjson1d9e6730DecodeGithubComJdavasligilEmodl6(in, &v15)
+					out.Badges = append(out.Badges, v15)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Users = make(map[string][]int)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v16 []int
+					if in.IsNull() {
+						in.Skip()
+						v16 = nil
+					} else {
+						in.Delim('[')
+						if v16 == nil {
+							if !in.IsDelim(']') {
+								v16 = make([]int, 0, 8)
+							} else {
+								v16 = []int{}
+							}
+						} else {
+							v16 = (v16)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v17 int
+							v17 = int(in.Int())
+							v16 = append(v16, v17)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					(out.Users)[key] = v16
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1d9e6730EncodeGithubComJdavasligilEmodl5(out *jwriter.Writer, in FFZBadgeResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"badges\":"
+		out.RawString(prefix[1:])
+		if in.Badges == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v18, v19 := range in.Badges {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				easyjson1d9e6730EncodeGithubComJdavasligilEmodl6(out, v19)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix)
+		if in.Users == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v20First := true
+			for v20Name, v20Value := range in.Users {
+				if v20First {
+					v20First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v20Name))
+				out.RawByte(':')
+				if v20Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v21, v22 := range v20Value {
+						if v21 > 0 {
+							out.RawByte(',')
+						}
+						out.Int(int(v22))
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FFZBadgeResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson1d9e6730EncodeGithubComJdavasligilEmodl5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FFZBadgeResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson1d9e6730EncodeGithubComJdavasligilEmodl5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FFZBadgeResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson1d9e6730DecodeGithubComJdavasligilEmodl5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FFZBadgeResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson1d9e6730DecodeGithubComJdavasligilEmodl5(l, v)
+}
+func easyjson1d9e6730DecodeGithubComJdavasligilEmodl6(in *jlexer.Lexer, out *FFZBadge) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "name":
+			out.Name = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		case "color":
+			out.Color = string(in.String())
+		case "urls":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.URLs = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v23 string
+					v23 = string(in.String())
+					(out.URLs)[key] = v23
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1d9e6730EncodeGithubComJdavasligilEmodl6(out *jwriter.Writer, in FFZBadge) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"color\":"
+		out.RawString(prefix)
+		out.String(string(in.Color))
+	}
+	{
+		const prefix string = ",\"urls\":"
+		out.RawString(prefix)
+		if in.URLs == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v24First := true
+			for v24Name, v24Value := range in.URLs {
+				if v24First {
+					v24First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v24Name))
+				out.RawByte(':')
+				out.String(string(v24Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
 }
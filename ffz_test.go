@@ -1,9 +1,13 @@
 package emodl
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestGetFFZEmoteSet(t *testing.T) {
-	sets, err := getFFZEmoteSets("global")
+	t.Parallel()
+	sets, err := getFFZEmoteSets(context.Background(), fakeAPIClient(t, ProviderFFZ), "global")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -11,8 +15,8 @@ func TestGetFFZEmoteSet(t *testing.T) {
 		t.Log("Sets must not be nil.")
 		t.Fail()
 	}
-	if len(sets) == 0 {
-		t.Log("Sets length is zero")
+	if len(sets) != 2 {
+		t.Logf("Expected 2 default sets, got %d", len(sets))
 		t.Fail()
 	}
 	// s, err := prettyPrint(sets)
@@ -23,16 +27,30 @@ func TestGetFFZEmoteSet(t *testing.T) {
 }
 
 func TestGetFFZRoomSet(t *testing.T) {
-	set, err := getFFZRoomEmoteSet("twitch", "39226538")
-	if err != nil {
-		t.Fatal(err)
-	}
-	if len(set.Emotes) == 0 {
-		t.Fatal("Emote set is empty")
+	t.Parallel()
+	c := fakeAPIClient(t, ProviderFFZ)
+	for _, tc := range []struct{ platform, id string }{
+		{"twitch", fakeTwitchID},
+		{"YouTube", "UCemodltest"},
+	} {
+		set, err := getFFZRoomEmoteSet(context.Background(), c, tc.platform, tc.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(set.Emotes) == 0 {
+			t.Fatalf("Emote set for %s is empty", tc.platform)
+		}
 	}
 	// s, err := prettyPrint(set)
 	// if err != nil {
 	// 	t.Fatal(err)
 	// }
 	// t.Log(s)
+
+	t.Run("UnknownRoom", func(t *testing.T) {
+		_, err := getFFZRoomEmoteSet(context.Background(), c, "twitch", "0")
+		if err == nil || err.Error() != "Unknown Room" {
+			t.Fatalf("Expected error message from API, got %v", err)
+		}
+	})
 }
@@ -0,0 +1,300 @@
+package emodl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mailru/easyjson"
+)
+
+// Version of the fixture file format written by RecordTransport. Fixtures
+// of any other version are rejected by ReplayTransport.
+const FixtureVersion = 1
+
+// Fixture is a recorded response of a provider API.
+//
+// JSON bodies are stored as JSON so fixtures can be diffed and compared
+// with CompareFixtures. Any other body is stored as a string.
+//
+//easyjson:json
+type Fixture struct {
+	Version  int                 `json:"version"`
+	Recorded string              `json:"recorded"`
+	Method   string              `json:"method"`
+	URL      string              `json:"url"`
+	Status   int                 `json:"status"`
+	Header   map[string][]string `json:"header"`
+	JSON     easyjson.RawMessage `json:"json,omitempty"`
+	Body     string              `json:"body,omitempty"`
+}
+
+// Returns the path of the fixture of a request relative to a fixture
+// directory: {host}/{path}.json, with the query appended to the file name
+// after an @ and a method prefix for anything other than GET.
+//
+// https://7tv.io/v3/users/twitch/1048391821 -> 7tv.io/v3/users/twitch/1048391821.json
+func FixturePath(method string, u *url.URL) string {
+	p := path.Clean("/" + u.Path)
+	if strings.HasSuffix(u.Path, "/") || p == "/" {
+		p = path.Join(p, "index")
+	}
+	dir, file := path.Split(p)
+	if method != "" && method != http.MethodGet {
+		file = method + "_" + file
+	}
+	if u.RawQuery != "" {
+		file += "@" + url.QueryEscape(u.RawQuery)
+	}
+	return filepath.FromSlash(path.Join(u.Host, dir, file+".json"))
+}
+
+// RecordTransport is an http.RoundTripper that saves every response it
+// receives as a Fixture in Dir.
+type RecordTransport struct {
+	// Directory the fixtures are written to
+	Dir string
+
+	// Transport used to send requests (http.DefaultTransport if nil)
+	Transport http.RoundTripper
+}
+
+func (t *RecordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	response, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	f := Fixture{
+		Version:  FixtureVersion,
+		Recorded: time.Now().UTC().Format(time.RFC3339),
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Status:   response.StatusCode,
+		Header:   response.Header.Clone(),
+	}
+	if json.Valid(body) {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, body, "", "  "); err != nil {
+			return nil, err
+		}
+		f.JSON = indented.Bytes()
+	} else {
+		f.Body = string(body)
+	}
+
+	if err := writeFixture(filepath.Join(t.Dir, FixturePath(req.Method, req.URL)), f); err != nil {
+		return nil, fmt.Errorf("emodl: %v: failure recording fixture for %s", err, req.URL)
+	}
+	return response, nil
+}
+
+func writeFixture(name string, f Fixture) error {
+	b, err := easyjson.Marshal(f)
+	if err != nil {
+		return err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, b, "", "  "); err != nil {
+		return err
+	}
+	indented.WriteByte('\n')
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, indented.Bytes(), 0o644)
+}
+
+// ReplayTransport is an http.RoundTripper that answers requests with the
+// fixtures saved in Dir by RecordTransport. Requests without a fixture fail.
+type ReplayTransport struct {
+	// Directory the fixtures are read from
+	Dir string
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	f, err := ReadFixture(filepath.Join(t.Dir, FixturePath(req.Method, req.URL)))
+	if err != nil {
+		return nil, fmt.Errorf("emodl: %v: no fixture for %s %s", err, req.Method, req.URL)
+	}
+
+	body := []byte(f.Body)
+	if len(f.JSON) > 0 {
+		body = f.JSON
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Status, http.StatusText(f.Status)),
+		StatusCode:    f.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header(f.Header).Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// ReadFixture reads a fixture file and checks its version.
+func ReadFixture(name string) (Fixture, error) {
+	var f Fixture
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return f, err
+	}
+	if err := easyjson.Unmarshal(b, &f); err != nil {
+		return f, err
+	}
+	if f.Version != FixtureVersion {
+		return f, fmt.Errorf("fixture %s has version %d, expected %d", name, f.Version, FixtureVersion)
+	}
+	return f, nil
+}
+
+// FixtureDrift describes how the JSON schema of a fixture changed between
+// two recordings. Fields are dot separated paths ending with their type,
+// with [] for array elements, e.g. "emotes[].data.animated:bool".
+type FixtureDrift struct {
+	// Path of the fixture relative to the fixture directories
+	Path    string
+	Added   []string
+	Removed []string
+
+	// Status codes of the old and new fixture (0 if missing)
+	Status [2]int
+}
+
+// CompareFixtures compares the fixtures recorded in two directories and
+// reports every fixture whose status or JSON schema differs. Fixtures
+// present in only one of the directories are reported as entirely added
+// or removed.
+func CompareFixtures(oldDir string, newDir string) ([]FixtureDrift, error) {
+	paths := make(map[string]struct{}, 32)
+	for _, dir := range []string{oldDir, newDir} {
+		err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Ext(p) != ".json" {
+				return err
+			}
+			rel, err := filepath.Rel(dir, p)
+			if err != nil {
+				return err
+			}
+			paths[rel] = struct{}{}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var drifts []FixtureDrift
+	for _, rel := range slices.Sorted(maps.Keys(paths)) {
+		oldShape, oldStatus, err := fixtureShape(filepath.Join(oldDir, rel))
+		if err != nil {
+			return drifts, err
+		}
+		newShape, newStatus, err := fixtureShape(filepath.Join(newDir, rel))
+		if err != nil {
+			return drifts, err
+		}
+
+		d := FixtureDrift{Path: rel, Status: [2]int{oldStatus, newStatus}}
+		for field := range newShape {
+			if _, ok := oldShape[field]; !ok {
+				d.Added = append(d.Added, field)
+			}
+		}
+		for field := range oldShape {
+			if _, ok := newShape[field]; !ok {
+				d.Removed = append(d.Removed, field)
+			}
+		}
+		if len(d.Added) > 0 || len(d.Removed) > 0 || oldStatus != newStatus {
+			slices.Sort(d.Added)
+			slices.Sort(d.Removed)
+			drifts = append(drifts, d)
+		}
+	}
+	return drifts, nil
+}
+
+// Returns the set of typed field paths of a fixture body and its status.
+// A missing fixture has an empty shape and status 0.
+func fixtureShape(name string) (map[string]struct{}, int, error) {
+	shape := make(map[string]struct{}, 64)
+	f, err := ReadFixture(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return shape, 0, nil
+	} else if err != nil {
+		return nil, 0, err
+	}
+	if len(f.JSON) == 0 {
+		return shape, f.Status, nil
+	}
+
+	var v any
+	if err := json.Unmarshal(f.JSON, &v); err != nil {
+		return nil, 0, fmt.Errorf("%v: fixture %s", err, name)
+	}
+	addShape(shape, "", v)
+	return shape, f.Status, nil
+}
+
+// Objects with keys that are IDs (like the FFZ sets map) would drift on
+// every recording, so keys made of digits are collapsed into "{id}".
+func addShape(shape map[string]struct{}, prefix string, v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		shape[prefix+":object"] = struct{}{}
+		for k, child := range v {
+			if strings.Trim(k, "0123456789") == "" {
+				k = "{id}"
+			}
+			if prefix != "" {
+				k = prefix + "." + k
+			}
+			addShape(shape, k, child)
+		}
+	case []any:
+		shape[prefix+":array"] = struct{}{}
+		for _, child := range v {
+			addShape(shape, prefix+"[]", child)
+		}
+	case string:
+		shape[prefix+":string"] = struct{}{}
+	case float64:
+		shape[prefix+":number"] = struct{}{}
+	case bool:
+		shape[prefix+":bool"] = struct{}{}
+	case nil:
+		shape[prefix+":null"] = struct{}{}
+	}
+}
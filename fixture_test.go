@@ -0,0 +1,155 @@
+package emodl
+
+import (
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFixturePath(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct{ method, url, want string }{
+		{"GET", "https://7tv.io/v3/users/twitch/1048391821", "7tv.io/v3/users/twitch/1048391821.json"},
+		{"GET", "https://api.frankerfacez.com/v1/room/yt/UCemodltest", "api.frankerfacez.com/v1/room/yt/UCemodltest.json"},
+		{"GET", "https://7tv.io/v3/users/twitch/", "7tv.io/v3/users/twitch/index.json"},
+		{"GET", "https://api.twitch.tv/helix/chat/emotes?broadcaster_id=1", "api.twitch.tv/helix/chat/emotes@broadcaster_id%3D1.json"},
+		{"POST", "https://example.com/a/b", "example.com/a/POST_b.json"},
+	} {
+		u, err := url.Parse(tc.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := FixturePath(tc.method, u); got != filepath.FromSlash(tc.want) {
+			t.Errorf("FixturePath(%s %s) = %s, want %s", tc.method, tc.url, got, tc.want)
+		}
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	srv := newFakeServer(t)
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	opt := fakeChannelOptions(srv)
+	opt.Client = &http.Client{Transport: &RecordTransport{Dir: dir}}
+	recorder := NewDownloader(opt)
+	recorded, err := recorder.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []string{
+		"bttv/3/cached/emotes/global.json",
+		"bttv/3/cached/users/twitch/" + fakeTwitchID + ".json",
+		"7tv/v3/emote-sets/global.json",
+		"7tv/v3/emote-sets/" + fakeSevenTVSetID + ".json",
+		"7tv/v3/users/twitch/" + fakeSevenTVTwitchID + ".json",
+		"ffz/v1/set/global.json",
+		"ffz/v1/room/id/" + fakeTwitchID + ".json",
+	} {
+		f, err := ReadFixture(filepath.Join(dir, host, filepath.FromSlash(p)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Status != http.StatusOK || len(f.JSON) == 0 {
+			t.Fatalf("Fixture %s recorded without JSON body: %+v", p, f)
+		}
+	}
+
+	opt.Client = &http.Client{Transport: &ReplayTransport{Dir: dir}}
+	srv.Close()
+	replayer := NewDownloader(opt)
+	replayed, err := replayer.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(slices.Sorted(maps.Keys(recorded)), slices.Sorted(maps.Keys(replayed))) {
+		t.Fatal("Replayed emotes differ from recorded emotes")
+	}
+
+	t.Run("MissingFixture", func(t *testing.T) {
+		opt := opt
+		opt.FFZ = &FFZOptions{Platform: "youtube", PlatformID: "UCemodltest"}
+		ed := NewDownloader(opt)
+		_, err := ed.Load()
+		if err == nil || !strings.Contains(err.Error(), "no fixture for GET") {
+			t.Fatalf("Expected missing fixture error, got %v", err)
+		}
+	})
+}
+
+// The fake server and ReplayTransport read the same testdata, so the
+// fixtures the suite uses can be recorded again with cmd/emodl-fixtures.
+func TestReplayTestdata(t *testing.T) {
+	t.Parallel()
+
+	opt := fakeChannelOptions(newFakeServer(t))
+	ed := NewDownloader(opt)
+	served, err := ed.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The default base URLs of the providers
+	opt.BaseURLs = nil
+	opt.Client = &http.Client{Transport: &ReplayTransport{Dir: "testdata"}}
+	ed = NewDownloader(opt)
+	replayed, err := ed.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(slices.Sorted(maps.Keys(served)), slices.Sorted(maps.Keys(replayed))) {
+		t.Fatal("Replayed testdata differs from the fake server")
+	}
+}
+
+func TestCompareFixtures(t *testing.T) {
+	t.Parallel()
+
+	oldDir, newDir := t.TempDir(), t.TempDir()
+	write := func(dir string, name string, f Fixture) {
+		t.Helper()
+		f.Version = FixtureVersion
+		if err := writeFixture(filepath.Join(dir, name), f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(oldDir, "set.json", Fixture{Status: 200, JSON: []byte(`{"sets":{"3":{"id":3,"name":"a"}}}`)})
+	write(newDir, "set.json", Fixture{Status: 200, JSON: []byte(`{"sets":{"4":{"id":4,"name":"b"}}}`)})
+	write(oldDir, "user.json", Fixture{Status: 200, JSON: []byte(`{"emotes":[{"id":"a","animated":false}]}`)})
+	write(newDir, "user.json", Fixture{Status: 200, JSON: []byte(`{"emotes":[{"id":"a","flags":0}]}`)})
+	write(newDir, "room.json", Fixture{Status: 404, Body: "not found"})
+
+	drifts, err := CompareFixtures(oldDir, newDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(drifts) != 2 {
+		t.Fatalf("Expected drift in room.json and user.json, got %+v", drifts)
+	}
+	if drifts[0].Path != "room.json" || drifts[0].Status != [2]int{0, 404} {
+		t.Fatalf("Unexpected drift for new fixture: %+v", drifts[0])
+	}
+	d := drifts[1]
+	if d.Path != "user.json" ||
+		!slices.Equal(d.Added, []string{"emotes[].flags:number"}) ||
+		!slices.Equal(d.Removed, []string{"emotes[].animated:bool"}) {
+		t.Fatalf("Unexpected drift: %+v", d)
+	}
+
+	t.Run("Version", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "old.json")
+		os.WriteFile(name, []byte(`{"version":0,"status":200}`), 0o644)
+		if _, err := ReadFixture(name); err == nil {
+			t.Fatal("No error reading fixture of another version")
+		}
+	})
+}
@@ -2,6 +2,9 @@ module github.com/jdavasligil/emodl
 
 go 1.24.2
 
-require github.com/mailru/easyjson v0.9.0
+require (
+	github.com/gorilla/websocket v1.5.3
+	github.com/mailru/easyjson v0.9.0
+)
 
 require github.com/josharian/intern v1.0.0 // indirect
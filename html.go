@@ -0,0 +1,150 @@
+package emodl
+
+import (
+	"cmp"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type HTMLOptions struct {
+	// Formats of the images in order of preference (imageFallbacks if
+	// empty). Every scale of an emote is in the first format it has.
+	Formats []string
+
+	// Theme of Twitch emote images ("dark" if empty)
+	Theme string
+
+	// Class of the img elements of emotes ("emote" if empty)
+	Class string
+}
+
+// Styles drawing the emotes of a group on top of one another.
+const (
+	htmlStackStyle   = "display: inline-grid; place-items: center; vertical-align: middle"
+	htmlOverlayStyle = "grid-area: 1 / 1"
+)
+
+// RenderHTML renders tokens, such as those of Compose, as HTML. Text is
+// escaped and emotes become img elements, e.g.
+//
+//	<img class="emote" src="https://cdn.7tv.app/emote/1/1x.webp" srcset="https://cdn.7tv.app/emote/1/1x.webp 1x, https://cdn.7tv.app/emote/1/2x.webp 2x" width="32" height="32" alt="EZ" title="EZ">
+//
+// The size of the img is the size of the smallest image. Emotes with
+// overlays are wrapped in a span drawing them on top of the emote, and
+// transforms are applied with inline styles, so no stylesheet is needed.
+func RenderHTML(tokens []Token, opt HTMLOptions) string {
+	if len(opt.Formats) == 0 {
+		opt.Formats = imageFallbacks[:]
+	}
+	if opt.Theme == "" {
+		opt.Theme = "dark"
+	}
+	if opt.Class == "" {
+		opt.Class = "emote"
+	}
+
+	var sb strings.Builder
+	for _, tok := range tokens {
+		if tok.Kind != TokenEmote {
+			sb.WriteString(html.EscapeString(tok.Text))
+			continue
+		}
+		if len(tok.Overlays) == 0 {
+			writeEmoteHTML(&sb, tok, "", opt)
+			continue
+		}
+		sb.WriteString(`<span style="` + htmlStackStyle + `">`)
+		writeEmoteHTML(&sb, tok, htmlOverlayStyle, opt)
+		for _, o := range tok.Overlays {
+			writeEmoteHTML(&sb, o, htmlOverlayStyle, opt)
+		}
+		sb.WriteString("</span>")
+	}
+	return sb.String()
+}
+
+// HTML renders a chat message as HTML with the emotes of the last Load.
+// See RenderHTML.
+func (ed *Downloader) HTML(message string, opt HTMLOptions) string {
+	return RenderHTML(ed.Compose(message), opt)
+}
+
+func writeEmoteHTML(sb *strings.Builder, tok Token, style string, opt HTMLOptions) {
+	e := tok.Emote
+	imgs := srcsetImages(e.Images, opt)
+	if len(imgs) == 0 {
+		// Nothing to draw, so keep the text
+		sb.WriteString(html.EscapeString(tok.Text))
+		return
+	}
+
+	width, height := imgs[0].Width, imgs[0].Height
+	if tok.Transform&TransformWide != 0 {
+		width *= 2
+	}
+	var transforms []string
+	if tok.Transform&TransformFlipX != 0 {
+		transforms = append(transforms, "scaleX(-1)")
+	}
+	if tok.Transform&TransformFlipY != 0 {
+		transforms = append(transforms, "scaleY(-1)")
+	}
+	if len(transforms) > 0 {
+		if style != "" {
+			style += "; "
+		}
+		style += "transform: " + strings.Join(transforms, " ")
+	}
+
+	srcset := make([]string, 0, len(imgs))
+	for _, img := range imgs {
+		srcset = append(srcset, srcsetURL(img.URL)+" "+strconv.Itoa(parseScale(img.Scale))+"x")
+	}
+
+	name := html.EscapeString(e.Name)
+	sb.WriteString(`<img class="` + html.EscapeString(opt.Class) + `"`)
+	sb.WriteString(` src="` + html.EscapeString(imgs[0].URL) + `"`)
+	sb.WriteString(` srcset="` + html.EscapeString(strings.Join(srcset, ", ")) + `"`)
+	if width > 0 && height > 0 {
+		sb.WriteString(` width="` + strconv.Itoa(width) + `" height="` + strconv.Itoa(height) + `"`)
+	}
+	sb.WriteString(` alt="` + name + `" title="` + name + `"`)
+	if style != "" {
+		sb.WriteString(` style="` + html.EscapeString(style) + `"`)
+	}
+	sb.WriteString(">")
+}
+
+// Returns an image of every scale in the first format of opt.Formats the
+// images have, or of any format if they have none, sorted by scale. The
+// size of the first image is in CSS pixels.
+func srcsetImages(imgs []Image, opt HTMLOptions) []Image {
+	for _, format := range append(slices.Clone(opt.Formats), "") {
+		var picked []Image
+		for _, img := range imgs {
+			if format != "" && !strings.EqualFold(img.Format, format) || img.Theme != "" && img.Theme != opt.Theme {
+				continue
+			}
+			i := slices.IndexFunc(picked, func(p Image) bool { return parseScale(p.Scale) == parseScale(img.Scale) })
+			if i < 0 {
+				picked = append(picked, img)
+			}
+		}
+		if len(picked) > 0 {
+			slices.SortFunc(picked, func(a, b Image) int { return cmp.Compare(parseScale(a.Scale), parseScale(b.Scale)) })
+			smallest := parseScale(picked[0].Scale)
+			picked[0].Width /= smallest
+			picked[0].Height /= smallest
+			return picked
+		}
+	}
+	return nil
+}
+
+// Commas and spaces separate the candidates of a srcset, so they are
+// escaped in URLs.
+func srcsetURL(url string) string {
+	return strings.NewReplacer(" ", "%20", ",", "%2C").Replace(url)
+}
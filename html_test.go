@@ -0,0 +1,86 @@
+package emodl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHTML(t *testing.T) {
+	t.Parallel()
+
+	images := func(id string, formats ...string) []Image {
+		var imgs []Image
+		for _, f := range formats {
+			for _, n := range []string{"2", "1"} {
+				imgs = append(imgs, Image{
+					URL:    "https://cdn.test/" + id + "/" + n + "x." + strings.ToLower(f),
+					Width:  28 * int(n[0]-'0'),
+					Height: 28 * int(n[0]-'0'),
+					Scale:  n + "x",
+					Format: f,
+				})
+			}
+		}
+		return imgs
+	}
+	m := EmoteMap{
+		"catJAM":   {Name: "catJAM", Images: images("cat", "GIF", "WEBP")},
+		"RainTime": {Name: "RainTime", ZeroWidth: true, Images: images("rain", "PNG")},
+		"<3":       {Name: "<3", Images: images("heart", "PNG")},
+		"Kappa": {Name: "Kappa", Images: []Image{
+			{URL: "https://cdn.test/kappa/light", Width: 28, Height: 28, Scale: "1x", Format: "PNG", Theme: "light"},
+			{URL: "https://cdn.test/kappa/dark", Width: 28, Height: 28, Scale: "1x", Format: "PNG", Theme: "dark"},
+		}},
+		"NoImages": {Name: "NoImages"},
+		"Comma":    {Name: "Comma", Images: []Image{{URL: "https://cdn.test/a,b c", Width: 10, Height: 10}}},
+	}
+
+	for _, tc := range []struct {
+		message string
+		opt     HTMLOptions
+		want    string
+	}{
+		{"<b>hi</b> & \"bye\"", HTMLOptions{}, "&lt;b&gt;hi&lt;/b&gt; &amp; &#34;bye&#34;"},
+		{"catJAM!", HTMLOptions{}, "catJAM!"},
+		{"a catJAM", HTMLOptions{}, `a <img class="emote" src="https://cdn.test/cat/1x.webp" srcset="https://cdn.test/cat/1x.webp 1x, https://cdn.test/cat/2x.webp 2x" width="28" height="28" alt="catJAM" title="catJAM">`},
+		{"catJAM", HTMLOptions{Formats: []string{"AVIF", "GIF"}, Class: "chat-emote"}, `<img class="chat-emote" src="https://cdn.test/cat/1x.gif" srcset="https://cdn.test/cat/1x.gif 1x, https://cdn.test/cat/2x.gif 2x" width="28" height="28" alt="catJAM" title="catJAM">`},
+		{"<3", HTMLOptions{}, `<img class="emote" src="https://cdn.test/heart/1x.png" srcset="https://cdn.test/heart/1x.png 1x, https://cdn.test/heart/2x.png 2x" width="28" height="28" alt="&lt;3" title="&lt;3">`},
+		{"Kappa", HTMLOptions{}, `<img class="emote" src="https://cdn.test/kappa/dark" srcset="https://cdn.test/kappa/dark 1x" width="28" height="28" alt="Kappa" title="Kappa">`},
+		{"Kappa", HTMLOptions{Theme: "light"}, `<img class="emote" src="https://cdn.test/kappa/light" srcset="https://cdn.test/kappa/light 1x" width="28" height="28" alt="Kappa" title="Kappa">`},
+		{"NoImages", HTMLOptions{}, "NoImages"},
+		{"Comma", HTMLOptions{}, `<img class="emote" src="https://cdn.test/a,b c" srcset="https://cdn.test/a%2Cb%20c 1x" width="10" height="10" alt="Comma" title="Comma">`},
+		{"w! h! catJAM", HTMLOptions{Formats: []string{"GIF"}}, `<img class="emote" src="https://cdn.test/cat/1x.gif" srcset="https://cdn.test/cat/1x.gif 1x, https://cdn.test/cat/2x.gif 2x" width="56" height="28" alt="catJAM" title="catJAM" style="transform: scaleX(-1)">`},
+		{"catJAM RainTime", HTMLOptions{Formats: []string{"GIF", "PNG"}}, `<span style="display: inline-grid; place-items: center; vertical-align: middle">` +
+			`<img class="emote" src="https://cdn.test/cat/1x.gif" srcset="https://cdn.test/cat/1x.gif 1x, https://cdn.test/cat/2x.gif 2x" width="28" height="28" alt="catJAM" title="catJAM" style="grid-area: 1 / 1">` +
+			`<img class="emote" src="https://cdn.test/rain/1x.png" srcset="https://cdn.test/rain/1x.png 1x, https://cdn.test/rain/2x.png 2x" width="28" height="28" alt="RainTime" title="RainTime" style="grid-area: 1 / 1">` +
+			`</span>`},
+	} {
+		if got := RenderHTML(m.Compose(tc.message), tc.opt); got != tc.want {
+			t.Errorf("%q:\nexpected %s\n     got %s", tc.message, tc.want, got)
+		}
+	}
+}
+
+func TestDownloaderHTML(t *testing.T) {
+	t.Parallel()
+	ed := NewDownloader(fakeChannelOptions(newFakeServer(t)))
+	if _, err := ed.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := ed.HTML("modCheck monkaS PartyParrot EZ", HTMLOptions{})
+	for _, want := range []string{
+		// BTTV 1x-3x
+		`srcset="https://cdn.betterttv.net/emote/5f0901cba2ac620530368579/1x.webp 1x, https://cdn.betterttv.net/emote/5f0901cba2ac620530368579/2x.webp 2x, https://cdn.betterttv.net/emote/5f0901cba2ac620530368579/3x.webp 3x" width="28" height="28" alt="modCheck"`,
+		// FFZ 1, 2 and 4 with the size of the emote
+		`srcset="https://cdn.frankerfacez.com/emote/128054/1 1x, https://cdn.frankerfacez.com/emote/128054/2 2x, https://cdn.frankerfacez.com/emote/128054/4 4x" width="36" height="32" alt="monkaS"`,
+		// Animated FFZ images are WEBP
+		`src="https://cdn.frankerfacez.com/emote/654321/animated/1.webp"`,
+		// 7TV host files
+		`https://cdn.7tv.app/emote/60ae3e98b2ecb0150535c6b7/4x.webp 4x" width="32" height="32" alt="EZ"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML missing %s:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package emodl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+)
+
+const defaultImageFetches = 8
+
+// ImageData is the content of a downloaded Image.
+//
+// Bytes may be shared with other fetches of the same image and must not be
+// modified.
+type ImageData struct {
+	Image       Image
+	ContentType string
+	Bytes       []byte
+}
+
+// ImageFetcher downloads the content of emote images.
+//
+// At most a fixed number of images are downloaded at once. Concurrent
+// fetches of images with the same ID share a single request.
+type ImageFetcher struct {
+	client    *http.Client
+	userAgent string
+	sem       chan struct{}
+	cache     *DiskCache
+
+	mu    sync.Mutex
+	calls map[string]*imageCall
+	sizes map[string]image.Point
+}
+
+// An in flight image request shared by every fetch of the same image. The
+// request is cancelled once every waiting fetch has given up.
+type imageCall struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+
+	data ImageData
+	err  error
+}
+
+// NewImageFetcher returns an ImageFetcher downloading at most concurrency
+// images at once. A nil client uses http.DefaultClient and a concurrency
+// below 1 uses a default of 8.
+func NewImageFetcher(client *http.Client, userAgent string, concurrency int) *ImageFetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if concurrency < 1 {
+		concurrency = defaultImageFetches
+	}
+	return &ImageFetcher{
+		client:    client,
+		userAgent: userAgent,
+		sem:       make(chan struct{}, concurrency),
+		calls:     make(map[string]*imageCall, 64),
+		sizes:     make(map[string]image.Point, 64),
+	}
+}
+
+// Fetch downloads the content of an image.
+func (f *ImageFetcher) Fetch(ctx context.Context, img Image) (ImageData, error) {
+	if img.URL == "" {
+		return ImageData{Image: img}, errors.New("emodl: image has no url")
+	}
+	key := imageKey(img)
+
+	f.mu.Lock()
+	c, ok := f.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &imageCall{done: make(chan struct{}), cancel: cancel}
+		f.calls[key] = c
+		go f.do(callCtx, key, img, c)
+	}
+	c.waiters++
+	f.mu.Unlock()
+
+	select {
+	case <-c.done:
+		data := c.data
+		data.Image = img
+		return data, c.err
+	case <-ctx.Done():
+		f.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			c.cancel()
+			if f.calls[key] == c {
+				delete(f.calls, key)
+			}
+		}
+		f.mu.Unlock()
+		return ImageData{Image: img}, ctx.Err()
+	}
+}
+
+// FetchAll downloads the content of every image. The returned slice has the
+// same order as imgs. Images that failed to download have no Bytes and
+// their errors are joined.
+func (f *ImageFetcher) FetchAll(ctx context.Context, imgs []Image) ([]ImageData, error) {
+	data := make([]ImageData, len(imgs))
+	errs := make([]error, len(imgs))
+
+	var wg sync.WaitGroup
+	for i, img := range imgs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data[i], errs[i] = f.Fetch(ctx, img)
+		}()
+	}
+	wg.Wait()
+
+	return data, errors.Join(errs...)
+}
+
+func (f *ImageFetcher) do(ctx context.Context, key string, img Image, c *imageCall) {
+	defer func() {
+		f.mu.Lock()
+		if f.calls[key] == c {
+			delete(f.calls, key)
+		}
+		f.mu.Unlock()
+		c.cancel()
+		close(c.done)
+	}()
+
+	select {
+	case f.sem <- struct{}{}:
+		defer func() { <-f.sem }()
+	case <-ctx.Done():
+		c.err = ctx.Err()
+		return
+	}
+
+	c.data, c.err = f.get(ctx, img)
+}
+
+func (f *ImageFetcher) get(ctx context.Context, img Image) (ImageData, error) {
+	data := ImageData{Image: img}
+	key := imageCacheKey(img)
+
+	if f.cache != nil {
+		if b, contentType, ok := f.cache.Get(key); ok {
+			data.Bytes, data.ContentType = b, contentType
+			return data, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", img.URL, nil)
+	if err != nil {
+		return data, err
+	}
+	if f.userAgent != "" {
+		req.Header.Set("User-Agent", f.userAgent)
+	}
+
+	response, err := f.client.Do(req)
+	if err != nil {
+		return data, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return data, fmt.Errorf("emodl: %s: failure getting image %s", response.Status, img.URL)
+	}
+
+	data.Bytes, err = io.ReadAll(response.Body)
+	if err != nil {
+		return data, err
+	}
+	data.ContentType = imageContentType(response.Header.Get("Content-Type"), data.Bytes)
+
+	if f.cache != nil {
+		err = f.cache.Put(CacheImages, key, data.ContentType, data.Bytes)
+	}
+	return data, err
+}
+
+// Only the image IDs of 7TV include the scale and format, so images are
+// cached by both ID and URL.
+func imageCacheKey(img Image) string {
+	return "image:" + img.ID + " " + img.URL
+}
+
+// Returns the media type of an image from its Content-Type header, or
+// sniffed from its content if the header is missing or too generic.
+func imageContentType(header string, b []byte) string {
+	if header != "" {
+		mediaType, _, err := mime.ParseMediaType(header)
+		if err == nil && mediaType != "application/octet-stream" {
+			return mediaType
+		}
+	}
+	// DetectContentType does not know AVIF
+	if len(b) >= 12 && string(b[4:12]) == "ftypavif" {
+		return "image/avif"
+	}
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(b))
+	return mediaType
+}
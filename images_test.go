@@ -0,0 +1,150 @@
+package emodl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testPNG(t testing.TB, w int, h int) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	if err := png.Encode(&b, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestImageFetcher(t *testing.T) {
+	t.Parallel()
+
+	pngBytes := testPNG(t, 28, 28)
+	var requests, inFlight, maxInFlight atomic.Int32
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		default:
+			// No Content-Type so the content has to be sniffed
+			w.Header()["Content-Type"] = nil
+			w.Write(pngBytes)
+		}
+	}))
+	defer srv.Close()
+
+	f := NewImageFetcher(srv.Client(), "", 2)
+
+	t.Run("Dedup", func(t *testing.T) {
+		requests.Store(0)
+		img := Image{URL: srv.URL + "/emote/1x", ID: "dedup"}
+
+		var wg sync.WaitGroup
+		results := make([]ImageData, 5)
+		errs := make([]error, 5)
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], errs[i] = f.Fetch(context.Background(), img)
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		release <- struct{}{}
+		wg.Wait()
+
+		if requests.Load() != 1 {
+			t.Fatalf("Expected 1 request for 5 fetches, got %d", requests.Load())
+		}
+		for i, data := range results {
+			if errs[i] != nil {
+				t.Fatal(errs[i])
+			}
+			if !bytes.Equal(data.Bytes, pngBytes) || data.ContentType != "image/png" {
+				t.Fatalf("Unexpected image data: %s %d bytes", data.ContentType, len(data.Bytes))
+			}
+		}
+	})
+
+	t.Run("Concurrency", func(t *testing.T) {
+		maxInFlight.Store(0)
+		imgs := make([]Image, 6)
+		for i := range imgs {
+			imgs[i] = Image{URL: fmt.Sprintf("%s/emote/%d", srv.URL, i), ID: fmt.Sprint(i)}
+		}
+
+		done := make(chan error)
+		go func() {
+			_, err := f.FetchAll(context.Background(), imgs)
+			done <- err
+		}()
+		for range imgs {
+			release <- struct{}{}
+		}
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+		if maxInFlight.Load() > 2 {
+			t.Fatalf("Expected at most 2 concurrent requests, got %d", maxInFlight.Load())
+		}
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		go func() { release <- struct{}{} }()
+		_, err := f.Fetch(context.Background(), Image{URL: srv.URL + "/missing", ID: "missing"})
+		if err == nil {
+			t.Fatal("No error for missing image")
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := f.Fetch(ctx, Image{URL: srv.URL + "/emote/slow", ID: "slow"})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected deadline exceeded, got %v", err)
+		}
+	})
+}
+
+func TestImageContentType(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		header string
+		b      []byte
+		want   string
+	}{
+		{"image/webp; charset=binary", nil, "image/webp"},
+		{"application/octet-stream", []byte("RIFF\x00\x00\x00\x00WEBPVP8 "), "image/webp"},
+		{"", []byte("\x00\x00\x00\x1cftypavif\x00\x00\x00\x00"), "image/avif"},
+		{"", []byte("GIF89a"), "image/gif"},
+	} {
+		if got := imageContentType(tc.header, tc.b); got != tc.want {
+			t.Errorf("imageContentType(%q) = %s, want %s", tc.header, got, tc.want)
+		}
+	}
+}
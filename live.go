@@ -0,0 +1,201 @@
+package emodl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultLiveMinBackoff = time.Second
+	defaultLiveMaxBackoff = 2 * time.Minute
+)
+
+// LiveOptions configures a client receiving emote changes over a provider
+// WebSocket.
+type LiveOptions struct {
+	// WebSocket URL of the provider (the public endpoint if empty)
+	URL string
+
+	// Dialer used to connect (websocket.DefaultDialer if nil)
+	Dialer *websocket.Dialer
+
+	// Called with the changes of every event that changed anything
+	OnChange func([]EmoteDiff)
+
+	// Called with every error that ended a connection
+	OnError func(error)
+
+	// Time waited before the first reconnect after a failure, doubled on
+	// every failure in a row up to MaxBackoff (1 second and 2 minutes if
+	// zero). Connections that fail before receiving any message after
+	// joining, and before staying up for MaxBackoff, count as failures.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Source of time (the system clock if nil)
+	Clock Clock
+}
+
+func (opt *LiveOptions) setDefaults(url string) {
+	if opt.URL == "" {
+		opt.URL = url
+	}
+	if opt.Dialer == nil {
+		opt.Dialer = websocket.DefaultDialer
+	}
+	if opt.MinBackoff <= 0 {
+		opt.MinBackoff = defaultLiveMinBackoff
+	}
+	if opt.MaxBackoff < opt.MinBackoff {
+		opt.MaxBackoff = max(defaultLiveMaxBackoff, opt.MinBackoff)
+	}
+	if opt.Clock == nil {
+		opt.Clock = systemClock{}
+	}
+}
+
+// Runs connections until ctx is done, waiting before every reconnect.
+// A connection returns whether it received a message after joining and
+// whether the next connection may start right away. The backoff is only
+// reset by connections that received a message or stayed up for
+// MaxBackoff, so that a server dropping every connection right away is
+// not redialed at MinBackoff forever.
+func runLive(ctx context.Context, opt LiveOptions, connect func(ctx context.Context) (ok bool, retryNow bool, err error)) error {
+	backoff := opt.MinBackoff
+	for {
+		start := time.Now()
+		ok, retryNow, err := connect(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && opt.OnError != nil {
+			opt.OnError(err)
+		}
+		if ok || time.Since(start) >= opt.MaxBackoff {
+			backoff = opt.MinBackoff
+		}
+		if retryNow {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-opt.Clock.After(backoff):
+		}
+		backoff = min(2*backoff, opt.MaxBackoff)
+	}
+}
+
+// Runs a live client in a new goroutine and returns a channel receiving
+// the changes of every event, replacing opt.OnChange. The channel is closed
+// once ctx is done.
+func startLive(ctx context.Context, opt *LiveOptions, run func(ctx context.Context) error) <-chan []EmoteDiff {
+	changes := make(chan []EmoteDiff)
+	opt.OnChange = func(diffs []EmoteDiff) {
+		select {
+		case changes <- diffs:
+		case <-ctx.Done():
+		}
+	}
+	go func() {
+		defer close(changes)
+		run(ctx)
+	}()
+	return changes
+}
+
+// Dials a provider WebSocket. The connection is closed once ctx is done so
+// that blocked reads return.
+func dialLive(ctx context.Context, opt LiveOptions, userAgent string) (*websocket.Conn, func(), error) {
+	header := http.Header{}
+	if userAgent != "" {
+		header.Set("User-Agent", userAgent)
+	}
+	conn, _, err := opt.Dialer.DialContext(ctx, opt.URL, header)
+	if err != nil {
+		return nil, nil, err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	return conn, func() {
+		stop()
+		conn.Close()
+	}, nil
+}
+
+// An incremental change to the emotes of a source pushed by a provider.
+// Emotes are matched by ID.
+type sourcePatch struct {
+	add    []ProviderEmote
+	update []ProviderEmote
+	remove []string
+}
+
+// Applies a patch to the source of a provider and updates the emote maps
+// in place. Updated emotes that are not loaded are added and added emotes
+// that are already loaded are updated.
+func (ed *Downloader) applyPatch(provider string, channel bool, patch sourcePatch) (EmoteDiff, error) {
+	d := EmoteDiff{Provider: provider}
+	i := slices.IndexFunc(ed.providers, func(p Provider) bool { return p.Name() == provider })
+	if i < 0 {
+		return d, fmt.Errorf("emodl: provider %s is not loaded", provider)
+	}
+	p := ed.providers[i]
+
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	key := sourceKey{provider: provider, channel: channel}
+	s := ed.sources[key]
+	index := func(id string) int {
+		return slices.IndexFunc(s.emotes, func(e Emote) bool { return e.ID == id })
+	}
+
+	var errs []error
+	for _, id := range patch.remove {
+		if i := index(id); i >= 0 {
+			d.Removed = append(d.Removed, s.emotes[i])
+			s.native = slices.Delete(s.native, i, i+1)
+			s.emotes = slices.Delete(s.emotes, i, i+1)
+		}
+	}
+	for _, pe := range slices.Concat(patch.add, patch.update) {
+		e, err := p.AsEmote(pe)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("emodl: %v: failure converting %s emote", err, provider))
+			continue
+		}
+		e.Provider = provider
+		if ed.Options.ProbeImages {
+			ed.images.backfill(e.Images)
+		}
+		if e.Scope == "" {
+			e.Scope = ScopeGlobal
+			if channel {
+				e.Scope = ScopeChannel
+			}
+		}
+		i := index(e.ID)
+		if i < 0 {
+			d.Added = append(d.Added, e)
+			s.native = append(s.native, pe)
+			s.emotes = append(s.emotes, e)
+			continue
+		}
+		if s.emotes[i].Name != e.Name {
+			d.Renamed = append(d.Renamed, EmoteRename{OldName: s.emotes[i].Name, Emote: e})
+		}
+		s.native[i] = pe
+		s.emotes[i] = e
+	}
+
+	ed.sources[key] = s
+	ed.merge()
+	return d, errors.Join(errs...)
+}
@@ -0,0 +1,122 @@
+package emodl
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Lookup returns every loaded emote named name. The emote in the map
+// returned by Load comes first, followed by the emotes it shadows.
+func (ed *Downloader) Lookup(name string) []Emote {
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+
+	cs := ed.candidates[name]
+	emotes := make([]Emote, 0, len(cs))
+	for _, c := range cs {
+		emotes = append(emotes, c.emote)
+	}
+	return emotes
+}
+
+// URL returns the image of the emote named name nearest to a scale ("1x",
+// "2x", "3x" or "4x") and format ("WEBP", "AVIF", "PNG", "GIF", etc.),
+// whichever provider it is from.
+//
+// Larger scales are preferred if the requested scale is missing. If the
+// format is missing, the image is in a format of imageFallbacks.
+func (ed *Downloader) URL(name string, scale string, format string) (Image, error) {
+	ed.mu.RLock()
+	cs := ed.candidates[name]
+	ed.mu.RUnlock()
+	if len(cs) == 0 {
+		return Image{}, fmt.Errorf("emodl: no emote named %s", name)
+	}
+	return cs[0].image(scale, format)
+}
+
+// Returns the image of a candidate from its native emote.
+func (c candidate) image(scale string, format string) (Image, error) {
+	switch e := c.native.(type) {
+	case SevenTVEmote:
+		return e.GetImage(strconv.Itoa(parseScale(scale))+"x", format)
+	}
+	return c.emote.Best(scale, append([]string{format}, imageFallbacks[:]...)...)
+}
+
+// Best returns the image of the emote nearest to a scale ("1x", "2x", etc.)
+// in one of the accepted formats, e.g.
+//
+//	img, err := e.Best("2x", "AVIF", "WEBP", "PNG")
+//
+// Larger scales are preferred if the requested scale is missing. Earlier
+// formats are preferred at the same scale, then dark theme images. Every
+// format is accepted if none is given.
+func (e Emote) Best(scale string, formats ...string) (Image, error) {
+	img, ok := bestImage(e.Images, scale, formats)
+	if !ok {
+		return Image{}, fmt.Errorf("emodl: %s emote %s has no %s images", e.Provider, e.Name, strings.Join(formats, " or "))
+	}
+	return img, nil
+}
+
+// Returns the image of imgs nearest to a scale in the first format of
+// formats it can, ignoring empty formats. Reports false if no image is in
+// any of the formats.
+func bestImage(imgs []Image, scale string, formats []string) (Image, bool) {
+	formats = slices.DeleteFunc(slices.Clone(formats), func(f string) bool { return f == "" })
+	rank := func(img Image) int {
+		if len(formats) == 0 {
+			return 0
+		}
+		return slices.IndexFunc(formats, func(f string) bool { return strings.EqualFold(f, img.Format) })
+	}
+
+	var scales []int
+	for _, img := range imgs {
+		if rank(img) >= 0 {
+			scales = append(scales, parseScale(img.Scale))
+		}
+	}
+	if len(scales) == 0 {
+		return Image{}, false
+	}
+	slices.Sort(scales)
+	want := nearestScale(parseScale(scale), scales)
+
+	var best Image
+	bestRank := -1
+	for _, img := range imgs {
+		r := rank(img)
+		if r < 0 || parseScale(img.Scale) != want {
+			continue
+		}
+		if bestRank < 0 || r < bestRank || r == bestRank && img.Theme == "dark" && best.Theme != "dark" {
+			best, bestRank = img, r
+		}
+	}
+	return best, true
+}
+
+// Returns the multiplier of a scale such as "2x", "2" or "2.0", or 1 if the
+// scale is invalid.
+func parseScale(scale string) int {
+	f, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(scale), "x"), 64)
+	if err != nil || f < 1 {
+		return 1
+	}
+	return int(f)
+}
+
+// Returns the smallest of the sorted scales that is at least n, or the
+// largest scale if there is none.
+func nearestScale(n int, scales []int) int {
+	for _, s := range scales {
+		if s >= n {
+			return s
+		}
+	}
+	return scales[len(scales)-1]
+}
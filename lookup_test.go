@@ -0,0 +1,105 @@
+package emodl
+
+import (
+	"testing"
+)
+
+func TestDownloaderLookup(t *testing.T) {
+	t.Parallel()
+	srv := newFakeServer(t)
+	ed := NewDownloader(fakeChannelOptions(srv))
+	if _, err := ed.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	emotes := ed.Lookup("monkaS")
+	if len(emotes) != 3 {
+		t.Fatalf("Expected monkaS of 3 providers, got %+v", emotes)
+	}
+	for i, p := range []string{ProviderFFZ, ProviderSevenTV, ProviderBTTV} {
+		if emotes[i].Provider != p {
+			t.Errorf("Candidate %d: expected %s, got %s", i, p, emotes[i].Provider)
+		}
+	}
+	if emotes[0].ID != ed.Emotes()["monkaS"].ID {
+		t.Error("First candidate is not the merged emote")
+	}
+	if len(ed.Lookup("NotAnEmote")) != 0 {
+		t.Error("Expected no candidates")
+	}
+
+	tests := []struct {
+		name, scale, format string
+		want                Image
+	}{
+		{"monkaS", "3x", "WEBP", Image{
+			URL: "https://cdn.frankerfacez.com/emote/128054/4", Width: 144, Height: 128, ID: "128054/4", Scale: "4x", Format: "PNG",
+		}},
+		{"modCheck", "4x", "GIF", Image{
+			URL: "https://cdn.betterttv.net/emote/5f0901cba2ac620530368579/3x", Width: 84, Height: 84, ID: "5f0901cba2ac620530368579/3x", Scale: "3x", Format: "GIF",
+		}},
+		{"modCheck", "2.0", "", Image{
+			URL: "https://cdn.betterttv.net/emote/5f0901cba2ac620530368579/2x.webp", Width: 56, Height: 56, ID: "5f0901cba2ac620530368579/2x.webp", Scale: "2x", Format: "WEBP",
+		}},
+		{"EZ", "2", "png", Image{
+			URL: "https://cdn.7tv.app/emote/60ae3e98b2ecb0150535c6b7/2x.png", Width: 64, Height: 64, ID: "60ae3e98b2ecb0150535c6b7+2x.png", Scale: "2x", Format: "PNG",
+		}},
+	}
+	for _, tt := range tests {
+		img, err := ed.URL(tt.name, tt.scale, tt.format)
+		if err != nil {
+			t.Errorf("%s %s %s: %v", tt.name, tt.scale, tt.format, err)
+			continue
+		}
+		if img != tt.want {
+			t.Errorf("%s %s %s: expected %+v, got %+v", tt.name, tt.scale, tt.format, tt.want, img)
+		}
+	}
+
+	if _, err := ed.URL("NotAnEmote", "1x", "WEBP"); err == nil {
+		t.Error("Expected error for a missing emote")
+	}
+}
+
+func TestEmoteBest(t *testing.T) {
+	t.Parallel()
+	ed := NewDownloader(fakeChannelOptions(newFakeServer(t)))
+	emotes, err := ed.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(emotes["modCheck"].Images); n != 6 {
+		t.Errorf("Expected 3 scales of WEBP and GIF modCheck, got %d images", n)
+	}
+	if n := len(emotes["PartyParrot"].Images); n != 6 {
+		t.Errorf("Expected 3 scales of PNG and WEBP PartyParrot, got %d images", n)
+	}
+
+	tests := []struct {
+		name, scale string
+		formats     []string
+		url         string
+		width       int
+	}{
+		{"PartyParrot", "2x", []string{"WEBP", "PNG"}, "https://cdn.frankerfacez.com/emote/654321/animated/2.webp", 64},
+		{"PartyParrot", "3x", []string{"PNG"}, "https://cdn.frankerfacez.com/emote/654321/4", 128},
+		{"monkaS", "1x", []string{"WEBP", "PNG"}, "https://cdn.frankerfacez.com/emote/128054/1", 36},
+		{"modCheck", "8x", nil, "https://cdn.betterttv.net/emote/5f0901cba2ac620530368579/3x.webp", 84},
+		{"EZ", "4x", []string{"AVIF"}, "https://cdn.7tv.app/emote/60ae3e98b2ecb0150535c6b7/4x.avif", 128},
+	}
+	for _, tt := range tests {
+		img, err := emotes[tt.name].Best(tt.scale, tt.formats...)
+		if err != nil {
+			t.Errorf("%s %s %v: %v", tt.name, tt.scale, tt.formats, err)
+			continue
+		}
+		if img.URL != tt.url || img.Width != tt.width {
+			t.Errorf("%s %s %v: expected %s at %d, got %+v", tt.name, tt.scale, tt.formats, tt.url, tt.width, img)
+		}
+	}
+
+	if _, err := emotes["monkaS"].Best("1x", "AVIF"); err == nil {
+		t.Error("Expected error for a missing format")
+	}
+}
@@ -0,0 +1,174 @@
+package emodl
+
+import (
+	"math"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Words are folded on the stack into a buffer of this many bytes, which is
+// only outgrown if an emote name is longer once folded.
+const matcherFoldBuffer = 64
+
+// Bytes of ASCII white space, as reported by unicode.IsSpace.
+var asciiSpace = [utf8.RuneSelf]bool{'\t': true, '\n': true, '\v': true, '\f': true, '\r': true, ' ': true}
+
+type MatcherOptions struct {
+	// Also match words that differ from an emote name only by case, e.g.
+	// "kappa" for Kappa. Emotes named exactly like the word still win.
+	CaseInsensitive bool
+}
+
+// Match is an occurrence of an emote in a message, at the byte offsets
+// Start (inclusive) to End (exclusive).
+type Match struct {
+	Start int
+	End   int
+
+	// Emote of the Matcher, which must not be modified
+	Emote *Emote
+}
+
+// Matcher finds emotes in chat messages the way Tokenize does, without
+// allocating. A Matcher is never changed once built, so it is safe for
+// concurrent use.
+type Matcher struct {
+	emotes []Emote
+	exact  map[string]int
+	folded map[string]int
+
+	// Bounds of the length of names in bytes, to skip lookups of words
+	// that cannot be emotes
+	minLen int
+	maxLen int
+
+	// Length of the longest folded name in bytes, past which words are
+	// not folded
+	maxFolded int
+}
+
+// NewMatcher returns a Matcher of a snapshot of emotes indexed by name.
+func NewMatcher(emotes map[string]Emote, opt MatcherOptions) *Matcher {
+	m := &Matcher{
+		emotes: make([]Emote, 0, len(emotes)),
+		exact:  make(map[string]int, len(emotes)),
+	}
+
+	// Sorted so the emote that wins a folded name is always the same
+	names := make([]string, 0, len(emotes))
+	for name := range emotes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	if opt.CaseInsensitive {
+		m.folded = make(map[string]int, len(emotes))
+	}
+	for i, name := range names {
+		m.emotes = append(m.emotes, emotes[name])
+		m.exact[name] = i
+		if i == 0 || len(name) < m.minLen {
+			m.minLen = len(name)
+		}
+		m.maxLen = max(m.maxLen, len(name))
+		if m.folded != nil {
+			b, _ := foldWord(nil, name, math.MaxInt)
+			key := string(b)
+			if _, ok := m.folded[key]; !ok {
+				m.folded[key] = i
+			}
+			m.maxFolded = max(m.maxFolded, len(key))
+		}
+	}
+	return m
+}
+
+// Append appends the emotes of a message to dst in order and returns the
+// extended slice. Nothing is allocated if dst has room for every match.
+func (m *Matcher) Append(dst []Match, message string) []Match {
+	start := -1
+	for i := 0; i < len(message); {
+		c, size := message[i], 1
+		var separator bool
+		if c < utf8.RuneSelf {
+			separator = asciiSpace[c]
+		} else {
+			var r rune
+			r, size = utf8.DecodeRuneInString(message[i:])
+			separator = isWordSeparator(r)
+		}
+
+		if separator {
+			if start >= 0 {
+				dst = m.appendWord(dst, message, start, i)
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+		i += size
+	}
+	if start >= 0 {
+		dst = m.appendWord(dst, message, start, len(message))
+	}
+	return dst
+}
+
+func (m *Matcher) appendWord(dst []Match, message string, start int, end int) []Match {
+	word := message[start:end]
+	if len(word) >= m.minLen && len(word) <= m.maxLen {
+		if i, ok := m.exact[word]; ok {
+			return append(dst, Match{Start: start, End: end, Emote: &m.emotes[i]})
+		}
+	}
+	if m.folded == nil {
+		return dst
+	}
+	// Folding may shorten words, so their length is only known once folded
+	var buf [matcherFoldBuffer]byte
+	key, ok := foldWord(buf[:0], word, m.maxFolded)
+	if !ok {
+		return dst
+	}
+	if i, ok := m.folded[string(key)]; ok {
+		return append(dst, Match{Start: start, End: end, Emote: &m.emotes[i]})
+	}
+	return dst
+}
+
+// Appends the lower case of a word to dst. Stops and reports false if it is
+// longer than limit bytes.
+func foldWord(dst []byte, word string, limit int) ([]byte, bool) {
+	n := 0
+	for i := 0; i < len(word); {
+		c := word[i]
+		if c < utf8.RuneSelf {
+			if n++; n > limit {
+				return dst, false
+			}
+			if 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			dst = append(dst, c)
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(word[i:])
+		r = unicode.ToLower(r)
+		if n += utf8.RuneLen(r); n > limit {
+			return dst, false
+		}
+		dst = utf8.AppendRune(dst, r)
+		i += size
+	}
+	return dst, true
+}
+
+// Matcher returns the Matcher of the emotes of the last Load or live
+// update. It is rebuilt on every change, so it never needs locking.
+func (ed *Downloader) Matcher() *Matcher {
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+	return ed.matcher
+}
@@ -0,0 +1,168 @@
+package emodl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMatcher(t *testing.T) {
+	t.Parallel()
+
+	emotes := EmoteMap{
+		"Kappa":  {ID: "25", Name: "Kappa"},
+		"KAPPA":  {ID: "26", Name: "KAPPA"},
+		"D:":     {ID: "2", Name: "D:"},
+		"catJAM": {ID: "3", Name: "catJAM"},
+		"Élan":   {ID: "4", Name: "Élan"},
+	}
+
+	for _, tc := range []struct {
+		message string
+		fold    bool
+		want    []string
+	}{
+		{"Kappa KAPPA kappa Kappa, D:", false, []string{"Kappa", "KAPPA", "D:"}},
+		{"Kappa KAPPA kappa KaPpA catjam", true, []string{"Kappa", "KAPPA", "kappa", "KaPpA", "catjam"}},
+		{"héllo　catJAM\U000E0000élan ÉLAN", false, []string{"catJAM"}},
+		{"héllo　catJAM\U000E0000élan ÉLAN", true, []string{"catJAM", "élan", "ÉLAN"}},
+		{"  ", true, nil},
+		{strings.Repeat("a", 200) + " catJAM", true, []string{"catJAM"}},
+		// The Kelvin sign is folded to a shorter k
+		{"\u212a\u212a\u212a\u212aappa \u212aappa", true, []string{"\u212aappa"}},
+	} {
+		m := NewMatcher(emotes, MatcherOptions{CaseInsensitive: tc.fold})
+		matches := m.Append(nil, tc.message)
+
+		var got []string
+		for _, match := range matches {
+			got = append(got, tc.message[match.Start:match.End])
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("%q (fold %t): expected %q, got %q", tc.message, tc.fold, tc.want, got)
+		}
+	}
+
+	// Exact names win over folded ones
+	m := NewMatcher(emotes, MatcherOptions{CaseInsensitive: true})
+	matches := m.Append(nil, "Kappa KAPPA kappa")
+	if matches[0].Emote.ID != "25" || matches[1].Emote.ID != "26" || matches[2].Emote.ID != "26" {
+		t.Errorf("Unexpected emotes %v %v %v", matches[0].Emote, matches[1].Emote, matches[2].Emote)
+	}
+
+	// Matches agree with Tokenize
+	message := "hi Kappa  Kappa, D: catJAM"
+	var emoteTokens []Token
+	for _, tok := range emotes.Tokenize(message) {
+		if tok.Kind == TokenEmote {
+			emoteTokens = append(emoteTokens, tok)
+		}
+	}
+	matches = NewMatcher(emotes, MatcherOptions{}).Append(nil, message)
+	if len(matches) != len(emoteTokens) {
+		t.Fatalf("Expected %d matches, got %d", len(emoteTokens), len(matches))
+	}
+	for i, match := range matches {
+		if match.Start != emoteTokens[i].Start || match.End != emoteTokens[i].End {
+			t.Errorf("Match %d at %d-%d, token at %d-%d", i, match.Start, match.End, emoteTokens[i].Start, emoteTokens[i].End)
+		}
+	}
+}
+
+func TestMatcherAllocs(t *testing.T) {
+	emotes := benchmarkEmotes()
+	message := benchmarkMessage()
+	for _, fold := range []bool{false, true} {
+		m := NewMatcher(emotes, MatcherOptions{CaseInsensitive: fold})
+		dst := make([]Match, 0, 64)
+		allocs := testing.AllocsPerRun(100, func() {
+			dst = m.Append(dst[:0], message)
+		})
+		if allocs != 0 {
+			t.Errorf("Fold %t: expected no allocations, got %v", fold, allocs)
+		}
+		if len(dst) != 6 {
+			t.Errorf("Fold %t: expected 6 matches, got %d", fold, len(dst))
+		}
+	}
+
+	// Words longer than any emote name, as in spam
+	m := NewMatcher(emotes, MatcherOptions{CaseInsensitive: true})
+	spam := strings.Repeat("A", 300) + " " + strings.Repeat("é", 100) + " Kappa"
+	dst := make([]Match, 0, 1)
+	allocs := testing.AllocsPerRun(100, func() {
+		dst = m.Append(dst[:0], spam)
+	})
+	if allocs != 0 || len(dst) != 1 {
+		t.Errorf("Expected a match and no allocations, got %d matches and %v allocations", len(dst), allocs)
+	}
+}
+
+func TestDownloaderMatcher(t *testing.T) {
+	t.Parallel()
+	opt := fakeChannelOptions(newFakeServer(t))
+	opt.Matcher.CaseInsensitive = true
+	ed := NewDownloader(opt)
+
+	if len(ed.Matcher().Append(nil, "monkaS")) != 0 {
+		t.Error("Expected no matches before Load")
+	}
+	if _, err := ed.Load(); err != nil {
+		t.Fatal(err)
+	}
+	matches := ed.Matcher().Append(nil, "monkas that was close MONKAS")
+	if len(matches) != 2 || matches[0].Emote.Provider != ProviderFFZ {
+		t.Errorf("Unexpected matches %+v", matches)
+	}
+}
+
+// A channel sized set of emotes.
+func benchmarkEmotes() EmoteMap {
+	emotes := make(EmoteMap, 1000)
+	for i := range 1000 {
+		name := fmt.Sprintf("emote%03dPog", i)
+		emotes[name] = Emote{ID: fmt.Sprint(i), Name: name}
+	}
+	for _, name := range []string{"Kappa", "catJAM", "monkaS", "OMEGALUL", "D:"} {
+		emotes[name] = Emote{ID: name, Name: name}
+	}
+	return emotes
+}
+
+// A chat message with 6 emotes among ordinary words.
+func benchmarkMessage() string {
+	return "that was so close monkaS I can't believe it OMEGALUL catJAM " +
+		"anyway emote123Pog what are we doing next stream Kappa D: ok lol"
+}
+
+func BenchmarkMatcher(b *testing.B) {
+	for _, fold := range []bool{false, true} {
+		b.Run(fmt.Sprintf("Fold=%t", fold), func(b *testing.B) {
+			m := NewMatcher(benchmarkEmotes(), MatcherOptions{CaseInsensitive: fold})
+			message := benchmarkMessage()
+			dst := make([]Match, 0, 64)
+			b.SetBytes(int64(len(message)))
+			b.ReportAllocs()
+			for b.Loop() {
+				dst = m.Append(dst[:0], message)
+			}
+		})
+	}
+}
+
+// The whitespace splitting map lookup Matcher replaces.
+func BenchmarkNaiveMap(b *testing.B) {
+	emotes := benchmarkEmotes()
+	message := benchmarkMessage()
+	b.SetBytes(int64(len(message)))
+	b.ReportAllocs()
+	for b.Loop() {
+		var found []Emote
+		for _, word := range strings.Fields(message) {
+			if e, ok := emotes[word]; ok {
+				found = append(found, e)
+			}
+		}
+		_ = found
+	}
+}
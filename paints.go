@@ -0,0 +1,284 @@
+package emodl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Functions of a Paint, named after their CSS functions.
+const (
+	PaintLinearGradient = "linear-gradient"
+	PaintRadialGradient = "radial-gradient"
+	PaintURL            = "url"
+)
+
+// Paint is a gradient or image drawn in place of the color of a user name.
+type Paint struct {
+	ID       string `json:"id"`
+	Provider string `json:"provider"`
+	Name     string `json:"name"`
+
+	// PaintLinearGradient, PaintRadialGradient or PaintURL
+	Function string `json:"function"`
+
+	// Solid color shown if the paint cannot be drawn
+	Color *color.NRGBA `json:"color"`
+
+	Stops  []PaintStop `json:"stops"`
+	Repeat bool        `json:"repeat"`
+
+	// Direction of linear gradients in degrees, clockwise from up
+	Angle float64 `json:"angle"`
+
+	// Shape of radial gradients ("circle" or "ellipse")
+	Shape string `json:"shape"`
+
+	// Image of url paints
+	ImageURL string `json:"image_url"`
+
+	Shadows []PaintShadow `json:"shadows"`
+
+	// Platform user IDs the paint is assigned to
+	Users []string `json:"users"`
+}
+
+// PaintStop is a color stop of a gradient at a position from 0 to 1.
+type PaintStop struct {
+	At    float64     `json:"at"`
+	Color color.NRGBA `json:"color"`
+}
+
+// PaintShadow is a drop shadow drawn under painted text, in pixels.
+type PaintShadow struct {
+	XOffset float64     `json:"x_offset"`
+	YOffset float64     `json:"y_offset"`
+	Radius  float64     `json:"radius"`
+	Color   color.NRGBA `json:"color"`
+}
+
+// CSS returns the declarations drawing the paint as the background of an
+// element, e.g.
+//
+//	background-image: linear-gradient(90deg, #ff0000ff 0%, #0000ffff 100%);
+//
+// followed by background-color and filter declarations if the paint has a
+// color or shadows. Paint text with background-clip: text and a transparent
+// color.
+func (p Paint) CSS() string {
+	var decls []string
+	if bg := p.background(); bg != "" {
+		decls = append(decls, "background-image: "+bg)
+	}
+	if p.Color != nil {
+		decls = append(decls, "background-color: "+cssColor(*p.Color))
+	}
+	if len(p.Shadows) > 0 {
+		shadows := make([]string, 0, len(p.Shadows))
+		for _, s := range p.Shadows {
+			shadows = append(shadows, fmt.Sprintf("drop-shadow(%spx %spx %spx %s)",
+				cssNumber(s.XOffset), cssNumber(s.YOffset), cssNumber(s.Radius), cssColor(s.Color)))
+		}
+		decls = append(decls, "filter: "+strings.Join(shadows, " "))
+	}
+	if len(decls) == 0 {
+		return ""
+	}
+	return strings.Join(decls, "; ") + ";"
+}
+
+// Returns the CSS image of the paint, or nothing if it has none.
+func (p Paint) background() string {
+	var sb strings.Builder
+	switch p.Function {
+	case PaintLinearGradient, PaintRadialGradient:
+		if len(p.Stops) == 0 {
+			return ""
+		}
+		if p.Repeat {
+			sb.WriteString("repeating-")
+		}
+		sb.WriteString(p.Function)
+		sb.WriteByte('(')
+		if p.Function == PaintLinearGradient {
+			sb.WriteString(cssNumber(p.Angle))
+			sb.WriteString("deg")
+		} else {
+			shape := p.Shape
+			if shape == "" {
+				shape = "circle"
+			}
+			sb.WriteString(shape)
+		}
+		for _, s := range p.Stops {
+			sb.WriteString(", ")
+			sb.WriteString(cssColor(s.Color))
+			sb.WriteByte(' ')
+			sb.WriteString(cssNumber(s.At * 100))
+			sb.WriteByte('%')
+		}
+		sb.WriteByte(')')
+	case PaintURL:
+		if p.ImageURL == "" {
+			return ""
+		}
+		sb.WriteString("url(")
+		sb.WriteString(strconv.Quote(p.ImageURL))
+		sb.WriteByte(')')
+	}
+	return sb.String()
+}
+
+func cssColor(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+func cssNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Rasterize draws the paint into a new image of the given size the way a
+// browser draws its CSS background. Shadows are not drawn.
+//
+// Paints with no stops, such as url paints, are filled with their color.
+// Returns an error if the paint has neither.
+func (p Paint) Rasterize(width int, height int) (*image.RGBA, error) {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	if len(p.Stops) == 0 {
+		if p.Color == nil {
+			return img, fmt.Errorf("emodl: paint %s has no stops or color", p.ID)
+		}
+		c := color.RGBAModel.Convert(*p.Color).(color.RGBA)
+		for i := 0; i < len(img.Pix); i += 4 {
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = c.R, c.G, c.B, c.A
+		}
+		return img, nil
+	}
+
+	var position func(x float64, y float64) float64
+	w, h := float64(width), float64(height)
+	cx, cy := w/2, h/2
+	switch p.Function {
+	case PaintLinearGradient:
+		// The gradient line passes through the center and is long enough
+		// for the corners to be at its ends.
+		sin, cos := math.Sincos(p.Angle * math.Pi / 180)
+		length := math.Abs(w*sin) + math.Abs(h*cos)
+		position = func(x float64, y float64) float64 {
+			return ((x-cx)*sin-(y-cy)*cos)/length + 0.5
+		}
+	case PaintRadialGradient:
+		// Gradients end at the farthest corner
+		rx, ry := math.Hypot(cx, cy), math.Hypot(cx, cy)
+		if p.Shape == "ellipse" {
+			rx, ry = cx*math.Sqrt2, cy*math.Sqrt2
+		}
+		position = func(x float64, y float64) float64 {
+			return math.Hypot((x-cx)/rx, (y-cy)/ry)
+		}
+	case PaintURL:
+		return img, fmt.Errorf("emodl: paint %s is an image", p.ID)
+	default:
+		return img, fmt.Errorf("emodl: paint %s has unknown function %q", p.ID, p.Function)
+	}
+
+	for y := range height {
+		for x := range width {
+			c := p.colorAt(position(float64(x)+0.5, float64(y)+0.5))
+			i := img.PixOffset(x, y)
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = c.R, c.G, c.B, c.A
+		}
+	}
+	return img, nil
+}
+
+// Returns the premultiplied color of the gradient at a position.
+func (p Paint) colorAt(t float64) color.RGBA {
+	first, last := p.Stops[0], p.Stops[len(p.Stops)-1]
+	if span := last.At - first.At; p.Repeat && span > 0 {
+		t = first.At + span*(((t-first.At)/span)-math.Floor((t-first.At)/span))
+	}
+
+	if t <= first.At {
+		return color.RGBAModel.Convert(first.Color).(color.RGBA)
+	}
+	for i := 1; i < len(p.Stops); i++ {
+		a, b := p.Stops[i-1], p.Stops[i]
+		if t > b.At {
+			continue
+		}
+		f := 0.0
+		if b.At > a.At {
+			f = (t - a.At) / (b.At - a.At)
+		}
+		ca := color.RGBAModel.Convert(a.Color).(color.RGBA)
+		cb := color.RGBAModel.Convert(b.Color).(color.RGBA)
+		lerp := func(x uint8, y uint8) uint8 {
+			return uint8(math.Round(float64(x) + f*(float64(y)-float64(x))))
+		}
+		return color.RGBA{R: lerp(ca.R, cb.R), G: lerp(ca.G, cb.G), B: lerp(ca.B, cb.B), A: lerp(ca.A, cb.A)}
+	}
+	return color.RGBAModel.Convert(last.Color).(color.RGBA)
+}
+
+// LoadPaints loads the paints of every provider implementing
+// PaintProvider.
+//
+// Downloading is bounded by a timeout of downloadTimeout seconds.
+func (ed *Downloader) LoadPaints() error {
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout*time.Second)
+	defer cancel()
+	return ed.LoadPaintsContext(ctx)
+}
+
+// LoadPaintsContext is like LoadPaints but cancellation and deadlines of
+// ctx apply to every request in flight.
+//
+// If a request fails, the paints of its last successful load are kept.
+func (ed *Downloader) LoadPaintsContext(ctx context.Context) error {
+	var pps []Provider
+	for _, p := range ed.providers {
+		if _, ok := p.(PaintProvider); ok {
+			pps = append(pps, p)
+		}
+	}
+	results, err := fetchAll(ctx, pps, "paints", func(p Provider) ([]Paint, error) {
+		return p.(PaintProvider).Paints(ctx)
+	})
+
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	for _, r := range results {
+		if r.err != nil {
+			err = errors.Join(err, r.err)
+			continue
+		}
+		ed.paintSources[r.provider] = r.values
+	}
+
+	// Users have a single paint, the one of the first provider
+	clear(ed.paints)
+	for i := len(ed.providers) - 1; i >= 0; i-- {
+		for _, p := range ed.paintSources[ed.providers[i].Name()] {
+			for _, u := range p.Users {
+				ed.paints[u] = p
+			}
+		}
+	}
+	return err
+}
+
+// Paint returns the paint of a platform user ID.
+func (ed *Downloader) Paint(userID string) (Paint, bool) {
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+	p, ok := ed.paints[userID]
+	return p, ok
+}
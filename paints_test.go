@@ -0,0 +1,106 @@
+package emodl
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestDownloaderPaints(t *testing.T) {
+	t.Parallel()
+	srv := newFakeServer(t)
+	ed := NewDownloader(fakeChannelOptions(srv))
+	if err := ed.LoadPaints(); err != nil {
+		t.Fatal(err)
+	}
+
+	for user, want := range map[string]string{
+		// Selected by the 7TV user of the channel
+		fakeSevenTVTwitchID: "Sunset",
+		"19264788":          "Sunset",
+		"24377667":          "Spotlight",
+		fakeTwitchID:        "Stars",
+	} {
+		p, ok := ed.Paint(user)
+		if !ok || p.Name != want {
+			t.Errorf("Expected paint %s of user %s, got %+v", want, user, p)
+		}
+	}
+	if p, ok := ed.Paint("1"); ok {
+		t.Errorf("Expected no paint, got %+v", p)
+	}
+
+	sunset, _ := ed.Paint(fakeSevenTVTwitchID)
+	if css := sunset.CSS(); css != "background-image: linear-gradient(90deg, #ff0000ff 0%, #0000ffff 100%); filter: drop-shadow(0px 0px 1px #000000ff);" {
+		t.Errorf("Unexpected CSS %q", css)
+	}
+	stars, _ := ed.Paint(fakeTwitchID)
+	if css := stars.CSS(); css != `background-image: url("https://cdn.7tv.app/paint/01GF8Y7K6G0003SZ5B0AEXTW7H/layer/01GF8Y7K6G0003SZ5B0AEXTW7J/1x.webp"); background-color: #ffaa00ff;` {
+		t.Errorf("Unexpected CSS %q", css)
+	}
+	stars.Color = nil
+	if _, err := stars.Rasterize(4, 4); err == nil {
+		t.Error("Expected error rasterizing an image paint without color")
+	}
+}
+
+func TestPaintRasterize(t *testing.T) {
+	t.Parallel()
+	red := color.NRGBA{R: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+
+	linear := Paint{
+		Function: PaintLinearGradient,
+		Angle:    90,
+		Stops:    []PaintStop{{At: 0, Color: red}, {At: 1, Color: blue}},
+	}
+	img, err := linear.Rasterize(4, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x, want := range []color.RGBA{
+		{R: 223, B: 32, A: 255},
+		{R: 159, B: 96, A: 255},
+		{R: 96, B: 159, A: 255},
+		{R: 32, B: 223, A: 255},
+	} {
+		if got := img.RGBAAt(x, 0); got != want {
+			t.Errorf("Linear pixel %d: expected %v, got %v", x, want, got)
+		}
+	}
+
+	// Top to bottom
+	linear.Angle = 180
+	img, _ = linear.Rasterize(1, 4)
+	if got := img.RGBAAt(0, 0); got != (color.RGBA{R: 223, B: 32, A: 255}) {
+		t.Errorf("Expected red at the top, got %v", got)
+	}
+
+	// Stops halfway repeat once more
+	linear.Angle = 90
+	linear.Repeat = true
+	linear.Stops[1].At = 0.5
+	img, _ = linear.Rasterize(4, 1)
+	if a, b := img.RGBAAt(0, 0), img.RGBAAt(2, 0); a != b {
+		t.Errorf("Expected repeated colors, got %v and %v", a, b)
+	}
+
+	// Colors are interpolated premultiplied
+	radial := Paint{
+		Function: PaintRadialGradient,
+		Shape:    "circle",
+		Stops:    []PaintStop{{At: 0, Color: color.NRGBA{255, 255, 255, 255}}, {At: 1, Color: color.NRGBA{}}},
+	}
+	img, err = radial.Rasterize(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := img.RGBAAt(1, 1); got != (color.RGBA{128, 128, 128, 128}) {
+		t.Errorf("Expected half transparent white, got %v", got)
+	}
+
+	fill := Paint{Function: PaintURL, Color: &red}
+	img, err = fill.Rasterize(1, 1)
+	if err != nil || img.RGBAAt(0, 0) != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("Expected fill with color, got %v %v", img.RGBAAt(0, 0), err)
+	}
+}
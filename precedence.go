@@ -0,0 +1,85 @@
+package emodl
+
+import (
+	"slices"
+)
+
+// Scopes of an emote. Shared emotes are emotes of other users added to a
+// channel, and rank like channel emotes.
+const (
+	ScopeGlobal  = "global"
+	ScopeChannel = "channel"
+	ScopeShared  = "shared"
+)
+
+// Precedence decides which emote wins when emotes of several providers or
+// scopes share a name. The zero value layers channel emotes over global
+// emotes, and later registered providers over earlier ones.
+type Precedence struct {
+	// Provider names from the highest precedence to the lowest. Providers
+	// missing from the list come after it in reverse registration order.
+	Providers []string
+
+	// If set, emotes of a provider win over every emote of providers of
+	// lower precedence, and channel emotes only win over global emotes of
+	// the same provider.
+	ProviderFirst bool
+
+	// Provider that wins for an emote name if it has an emote of that name
+	Overrides map[string]string
+}
+
+// Returns the names of the providers from the highest precedence to the
+// lowest.
+func (pr Precedence) providerOrder(providers []Provider) []string {
+	names := make([]string, 0, len(providers))
+	for _, name := range pr.Providers {
+		if !slices.Contains(names, name) && slices.ContainsFunc(providers, func(p Provider) bool { return p.Name() == name }) {
+			names = append(names, name)
+		}
+	}
+	for _, p := range slices.Backward(providers) {
+		if !slices.Contains(names, p.Name()) {
+			names = append(names, p.Name())
+		}
+	}
+	return names
+}
+
+// Returns the sources of the providers from the highest precedence to the
+// lowest.
+func (pr Precedence) sourceOrder(providers []Provider) []sourceKey {
+	names := pr.providerOrder(providers)
+	keys := make([]sourceKey, 0, 2*len(names))
+	if pr.ProviderFirst {
+		for _, name := range names {
+			keys = append(keys,
+				sourceKey{provider: name, channel: true},
+				sourceKey{provider: name, channel: false})
+		}
+		return keys
+	}
+	for _, channel := range []bool{true, false} {
+		for _, name := range names {
+			keys = append(keys, sourceKey{provider: name, channel: channel})
+		}
+	}
+	return keys
+}
+
+// Moves the candidates of the overriding provider of a name to the front.
+func (pr Precedence) override(name string, cs []candidate) {
+	provider, ok := pr.Overrides[name]
+	if !ok {
+		return
+	}
+	slices.SortStableFunc(cs, func(a, b candidate) int {
+		switch {
+		case a.emote.Provider == provider && b.emote.Provider != provider:
+			return -1
+		case a.emote.Provider != provider && b.emote.Provider == provider:
+			return 1
+		}
+		return 0
+	})
+}
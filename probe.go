@@ -0,0 +1,240 @@
+package emodl
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Bytes of an image read to find its size. Enough for the headers of every
+// supported format.
+const probeBytes = 4096
+
+// Probe returns img with the Width and Height read from the header of the
+// image. Only the first bytes of the image are downloaded, or none if the
+// size of an image with the same ID is known or the image is cached.
+//
+// PNG, GIF, WebP and AVIF images are supported.
+func (f *ImageFetcher) Probe(ctx context.Context, img Image) (Image, error) {
+	if img.URL == "" {
+		return img, errors.New("emodl: image has no url")
+	}
+	if size, ok := f.size(img); ok {
+		img.Width, img.Height = size.X, size.Y
+		return img, nil
+	}
+
+	select {
+	case f.sem <- struct{}{}:
+		defer func() { <-f.sem }()
+	case <-ctx.Done():
+		return img, ctx.Err()
+	}
+
+	b, err := f.header(ctx, img)
+	if err != nil {
+		return img, err
+	}
+	w, h, err := imageSize(b)
+	if err != nil {
+		return img, fmt.Errorf("emodl: %v: failure probing image %s", err, img.URL)
+	}
+
+	f.mu.Lock()
+	f.sizes[imageKey(img)] = image.Pt(w, h)
+	f.mu.Unlock()
+
+	img.Width, img.Height = w, h
+	return img, nil
+}
+
+// ProbeAll probes every image concurrently and updates the Width and Height
+// of imgs in place. Images that failed to probe keep their size and their
+// errors are joined.
+func (f *ImageFetcher) ProbeAll(ctx context.Context, imgs []Image) error {
+	errs := make([]error, len(imgs))
+
+	var wg sync.WaitGroup
+	for i := range imgs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			imgs[i], errs[i] = f.Probe(ctx, imgs[i])
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Probes the images of every emote in place.
+func (f *ImageFetcher) probeEmotes(ctx context.Context, emotes []Emote) error {
+	var errs []error
+	for _, e := range emotes {
+		errs = append(errs, f.ProbeAll(ctx, e.Images))
+	}
+	return errors.Join(errs...)
+}
+
+// Sets the size of the images already probed, without downloading.
+func (f *ImageFetcher) backfill(imgs []Image) {
+	for i := range imgs {
+		if size, ok := f.size(imgs[i]); ok {
+			imgs[i].Width, imgs[i].Height = size.X, size.Y
+		}
+	}
+}
+
+func (f *ImageFetcher) size(img Image) (image.Point, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	size, ok := f.sizes[imageKey(img)]
+	return size, ok
+}
+
+// Returns the first bytes of an image, from the cache if it has the image.
+func (f *ImageFetcher) header(ctx context.Context, img Image) ([]byte, error) {
+	if f.cache != nil {
+		if b, _, ok := f.cache.Get(imageCacheKey(img)); ok {
+			return b, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", img.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", probeBytes-1))
+	if f.userAgent != "" {
+		req.Header.Set("User-Agent", f.userAgent)
+	}
+
+	response, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	// Servers may ignore the range and send the whole image
+	if response.StatusCode != http.StatusPartialContent && response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("emodl: %s: failure probing image %s", response.Status, img.URL)
+	}
+	return io.ReadAll(io.LimitReader(response.Body, probeBytes))
+}
+
+// Images are told apart by ID like in Fetch.
+func imageKey(img Image) string {
+	if img.ID == "" {
+		return img.URL
+	}
+	return img.ID
+}
+
+var errImageTruncated = errors.New("image header is truncated")
+
+// Returns the size of a PNG, GIF, WebP or AVIF image from its first bytes.
+func imageSize(b []byte) (int, int, error) {
+	switch {
+	case len(b) >= 8 && string(b[:8]) == "\x89PNG\r\n\x1a\n":
+		if len(b) < 24 || string(b[12:16]) != "IHDR" {
+			return 0, 0, errImageTruncated
+		}
+		return int(binary.BigEndian.Uint32(b[16:])), int(binary.BigEndian.Uint32(b[20:])), nil
+
+	case len(b) >= 6 && (string(b[:6]) == "GIF87a" || string(b[:6]) == "GIF89a"):
+		if len(b) < 10 {
+			return 0, 0, errImageTruncated
+		}
+		return int(binary.LittleEndian.Uint16(b[6:])), int(binary.LittleEndian.Uint16(b[8:])), nil
+
+	case len(b) >= 12 && string(b[:4]) == "RIFF" && string(b[8:12]) == "WEBP":
+		return webpSize(b)
+
+	case len(b) >= 8 && string(b[4:8]) == "ftyp":
+		return avifSize(b)
+	}
+	return 0, 0, errors.New("unknown image format")
+}
+
+// The first chunk of a WebP image is a lossy, lossless or extended header.
+// https://developers.google.com/speed/webp/docs/riff_container
+func webpSize(b []byte) (int, int, error) {
+	if len(b) < 30 {
+		return 0, 0, errImageTruncated
+	}
+	switch string(b[12:16]) {
+	case "VP8 ":
+		if b[23] != 0x9d || b[24] != 0x01 || b[25] != 0x2a {
+			return 0, 0, errors.New("invalid VP8 frame")
+		}
+		return int(binary.LittleEndian.Uint16(b[26:]) & 0x3fff), int(binary.LittleEndian.Uint16(b[28:]) & 0x3fff), nil
+	case "VP8L":
+		if b[20] != 0x2f {
+			return 0, 0, errors.New("invalid VP8L signature")
+		}
+		bits := binary.LittleEndian.Uint32(b[21:])
+		return int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1, nil
+	case "VP8X":
+		w := uint32(b[24]) | uint32(b[25])<<8 | uint32(b[26])<<16
+		h := uint32(b[27]) | uint32(b[28])<<8 | uint32(b[29])<<16
+		return int(w) + 1, int(h) + 1, nil
+	}
+	return 0, 0, fmt.Errorf("unknown WebP chunk %q", b[12:16])
+}
+
+// AVIF images are sized by the ispe property of the meta box. The first
+// one is of the primary image.
+func avifSize(b []byte) (int, int, error) {
+	box := b
+	for _, typ := range []string{"meta", "iprp", "ipco", "ispe"} {
+		var ok bool
+		box, ok = findBox(box, typ)
+		if !ok {
+			return 0, 0, fmt.Errorf("no %s box: %w", typ, errImageTruncated)
+		}
+		// Full boxes start with a version and flags
+		if typ == "meta" {
+			if len(box) < 4 {
+				return 0, 0, errImageTruncated
+			}
+			box = box[4:]
+		}
+	}
+	if len(box) < 12 {
+		return 0, 0, errImageTruncated
+	}
+	return int(binary.BigEndian.Uint32(box[4:])), int(binary.BigEndian.Uint32(box[8:])), nil
+}
+
+// Returns the content of the first ISO BMFF box of a type in b. The content
+// of a box cut off by the end of b is returned as far as it goes.
+func findBox(b []byte, typ string) ([]byte, bool) {
+	for len(b) >= 8 {
+		size, header := uint64(binary.BigEndian.Uint32(b)), uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(b))
+		case 1:
+			if len(b) < 16 {
+				return nil, false
+			}
+			size, header = binary.BigEndian.Uint64(b[8:]), 16
+		}
+		if size < header {
+			return nil, false
+		}
+		if string(b[4:8]) == typ {
+			return b[header:min(size, uint64(len(b)))], true
+		}
+		if size >= uint64(len(b)) {
+			return nil, false
+		}
+		b = b[size:]
+	}
+	return nil, false
+}
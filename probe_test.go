@@ -0,0 +1,167 @@
+package emodl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Returns an ISO BMFF box of a type holding the concatenated content.
+func testBox(typ string, content ...[]byte) []byte {
+	body := bytes.Join(content, nil)
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	return append(append(b, typ...), body...)
+}
+
+// Returns a WebP image with a single chunk, padded to hold its header.
+func testWebP(chunk string, header ...byte) []byte {
+	b := append([]byte("RIFF\x00\x00\x00\x00WEBP"+chunk+"\x00\x00\x00\x00"), header...)
+	return append(b, make([]byte, 32)...)
+}
+
+func TestImageSize(t *testing.T) {
+	t.Parallel()
+
+	var gifBytes bytes.Buffer
+	palette := color.Palette{color.Black}
+	if err := gif.Encode(&gifBytes, image.NewPaletted(image.Rect(0, 0, 56, 48), palette), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The size is stored as 14 bits each, minus one
+	vp8l := binary.LittleEndian.AppendUint32([]byte{0x2f}, 111|55<<14)
+	ispe := binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(make([]byte, 4), 128), 96)
+	avif := bytes.Join([][]byte{
+		testBox("ftyp", []byte("avif\x00\x00\x00\x00mif1avif")),
+		testBox("meta", make([]byte, 4),
+			testBox("hdlr", make([]byte, 24)),
+			testBox("iprp", testBox("ipco", testBox("ispe", ispe)))),
+		testBox("mdat", make([]byte, 16)),
+	}, nil)
+
+	for _, tc := range []struct {
+		name string
+		b    []byte
+		w, h int
+	}{
+		{"PNG", testPNG(t, 28, 30), 28, 30},
+		{"GIF", gifBytes.Bytes(), 56, 48},
+		{"VP8", testWebP("VP8 ", 0, 0, 0, 0x9d, 0x01, 0x2a, 64, 0, 32, 0), 64, 32},
+		{"VP8L", testWebP("VP8L", vp8l...), 112, 56},
+		{"VP8X", testWebP("VP8X", 0, 0, 0, 0, 99, 0, 0, 49, 0, 0), 100, 50},
+		{"AVIF", avif, 128, 96},
+	} {
+		w, h, err := imageSize(tc.b)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		} else if w != tc.w || h != tc.h {
+			t.Errorf("%s: expected %dx%d, got %dx%d", tc.name, tc.w, tc.h, w, h)
+		}
+	}
+
+	for _, b := range [][]byte{
+		nil,
+		[]byte("not an image"),
+		testPNG(t, 28, 28)[:20],
+		avif[:40],
+	} {
+		if _, _, err := imageSize(b); err == nil {
+			t.Errorf("Expected error for %q", b)
+		}
+	}
+}
+
+func TestImageFetcherProbe(t *testing.T) {
+	t.Parallel()
+
+	pngBytes := append(testPNG(t, 56, 52), make([]byte, 2*probeBytes)...)
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Range") != "bytes=0-4095" {
+			t.Errorf("Unexpected range %q", r.Header.Get("Range"))
+		}
+		http.ServeContent(w, r, "emote.png", time.Time{}, bytes.NewReader(pngBytes))
+	}))
+	defer srv.Close()
+
+	f := NewImageFetcher(srv.Client(), "", 2)
+	img, err := f.Probe(context.Background(), Image{URL: srv.URL + "/emote/2x", ID: "emote/2x", Width: 56, Height: 56})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Width != 56 || img.Height != 52 {
+		t.Fatalf("Expected 56x52, got %dx%d", img.Width, img.Height)
+	}
+
+	// Known sizes are not probed again
+	imgs := []Image{
+		{URL: srv.URL + "/emote/2x", ID: "emote/2x"},
+		{URL: srv.URL + "/emote/3x", ID: "emote/3x"},
+	}
+	if err := f.ProbeAll(context.Background(), imgs); err != nil {
+		t.Fatal(err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests.Load())
+	}
+	for _, img := range imgs {
+		if img.Width != 56 || img.Height != 52 {
+			t.Errorf("%s: expected 56x52, got %dx%d", img.ID, img.Width, img.Height)
+		}
+	}
+}
+
+// Serves BTTV images with a width of 30 pixels per scale.
+type fakeCDNTransport struct {
+	next http.RoundTripper
+}
+
+func (tr fakeCDNTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.URL.Host != "cdn.betterttv.net" {
+		return tr.next.RoundTrip(r)
+	}
+	scale, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimSuffix(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], ".webp"), "x"))
+	w := httptest.NewRecorder()
+	if err := png.Encode(w, image.NewRGBA(image.Rect(0, 0, 30*scale, 28*scale))); err != nil {
+		return nil, err
+	}
+	return w.Result(), nil
+}
+
+func TestDownloaderProbeImages(t *testing.T) {
+	t.Parallel()
+
+	srv := newFakeServer(t)
+	opt := fakeChannelOptions(srv)
+	opt.Client = &http.Client{Transport: fakeCDNTransport{next: srv.Client().Transport}}
+	opt.ProbeImages = true
+	ed := NewDownloader(opt)
+
+	emotes, err := ed.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, img := range emotes["modCheck"].Images {
+		n := parseScale(img.Scale)
+		if img.Width != 30*n || img.Height != 28*n {
+			t.Errorf("%s: expected %dx%d, got %dx%d", img.ID, 30*n, 28*n, img.Width, img.Height)
+		}
+	}
+
+	// Other providers report their sizes
+	if img := emotes["EZ"].Images[0]; img.Width != 32 {
+		t.Errorf("Unexpected 7TV image %+v", img)
+	}
+}
@@ -0,0 +1,120 @@
+package emodl
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Names of the built in providers.
+const (
+	ProviderBTTV    = "BTTV"
+	ProviderSevenTV = "7TV"
+	ProviderFFZ     = "FFZ"
+	ProviderTwitch  = "Twitch"
+)
+
+// ProviderEmote is an emote in the native representation of its Provider,
+// such as BTTVEmote, FFZEmote or SevenTVEmote.
+type ProviderEmote any
+
+// Provider is a source of third party emotes.
+//
+// Custom providers are made available to every Downloader with
+// RegisterProvider.
+type Provider interface {
+	// Name identifies the provider in errors and reports.
+	Name() string
+
+	// GlobalEmotes fetches the emotes available in every channel.
+	GlobalEmotes(ctx context.Context) ([]ProviderEmote, error)
+
+	// ChannelEmotes fetches the emotes of the configured channel.
+	// Returns no emotes and no error if no channel is configured.
+	ChannelEmotes(ctx context.Context) ([]ProviderEmote, error)
+
+	// AsEmote converts an emote returned by this provider into an Emote.
+	AsEmote(e ProviderEmote) (Emote, error)
+}
+
+// BadgeProvider is a Provider that also has chat badges. Downloaders load
+// the badges of every provider implementing it.
+type BadgeProvider interface {
+	Provider
+
+	// Badges fetches every badge along with the platform user IDs it is
+	// assigned to.
+	Badges(ctx context.Context) ([]Badge, error)
+}
+
+// PaintProvider is a Provider that also has name paints. Downloaders load
+// the paints of every provider implementing it.
+type PaintProvider interface {
+	Provider
+
+	// Paints fetches every paint along with the platform user IDs it is
+	// assigned to.
+	Paints(ctx context.Context) ([]Paint, error)
+}
+
+// ProviderFactory builds a Provider from the options of a Downloader.
+// Returning nil disables the provider for that Downloader.
+type ProviderFactory func(opt DownloaderOptions) Provider
+
+var providerRegistry = struct {
+	sync.RWMutex
+	names     []string
+	factories map[string]ProviderFactory
+}{
+	factories: make(map[string]ProviderFactory, 4),
+}
+
+// RegisterProvider makes a provider available to every Downloader created
+// after the call. Providers are loaded in registration order.
+//
+// Panics if factory is nil or if name is already registered.
+func RegisterProvider(name string, factory ProviderFactory) {
+	providerRegistry.Lock()
+	defer providerRegistry.Unlock()
+
+	if factory == nil {
+		panic("emodl: RegisterProvider factory is nil")
+	}
+	if _, dup := providerRegistry.factories[name]; dup {
+		panic(fmt.Sprintf("emodl: RegisterProvider called twice for provider %s", name))
+	}
+	providerRegistry.names = append(providerRegistry.names, name)
+	providerRegistry.factories[name] = factory
+}
+
+// RegisteredProviders returns the names of all registered providers in
+// registration order.
+func RegisteredProviders() []string {
+	providerRegistry.RLock()
+	defer providerRegistry.RUnlock()
+
+	names := make([]string, len(providerRegistry.names))
+	copy(names, providerRegistry.names)
+	return names
+}
+
+func newProviders(opt DownloaderOptions) []Provider {
+	providerRegistry.RLock()
+	defer providerRegistry.RUnlock()
+
+	providers := make([]Provider, 0, len(providerRegistry.names))
+	for _, name := range providerRegistry.names {
+		p := providerRegistry.factories[name](opt)
+		if p != nil {
+			providers = append(providers, p)
+		}
+	}
+	return providers
+}
+
+func init() {
+	RegisterProvider(ProviderBTTV, newBTTVProvider)
+	RegisterProvider(ProviderSevenTV, newSevenTVProvider)
+	RegisterProvider(ProviderFFZ, newFFZProvider)
+	RegisterProvider(ProviderTwitch, newTwitchProvider)
+}
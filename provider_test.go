@@ -0,0 +1,22 @@
+package emodl
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRegisteredProviders(t *testing.T) {
+	names := RegisteredProviders()
+	if !slices.Equal(names[:4], []string{ProviderBTTV, ProviderSevenTV, ProviderFFZ, ProviderTwitch}) {
+		t.Fatalf("Built in providers registered out of order: %v", names)
+	}
+}
+
+func TestRegisterProviderDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("No panic registering a provider twice")
+		}
+	}()
+	RegisterProvider(ProviderBTTV, newBTTVProvider)
+}
@@ -9,19 +9,16 @@ package emodl
 // https://7tv.io/v3/emote-sets/global
 
 import (
+	"context"
 	"errors"
-	"io"
-	"net/http"
-	"net/url"
+	"fmt"
 	"strings"
+	"sync"
 	"unsafe"
-
-	"github.com/mailru/easyjson"
 )
 
 var (
 	sevenTVAPIVersion = "v3"
-	sevenTVHost       = "7tv.io"
 )
 
 // Either SevenTVID or Platform/PlatformID are needed to get user emote sets.
@@ -38,12 +35,20 @@ type SevenTVOptions struct {
 
 //easyjson:json
 type SevenTVUser struct {
-	ID        string `json:"id"`
+	ID        string           `json:"id"`
+	Style     SevenTVUserStyle `json:"style"`
 	EmoteSets []struct {
 		ID string `json:"id"`
 	} `json:"emote_sets"`
 }
 
+// Cosmetics selected by a user.
+type SevenTVUserStyle struct {
+	Color   int32  `json:"color"`
+	PaintID string `json:"paint_id"`
+	BadgeID string `json:"badge_id"`
+}
+
 //easyjson:json
 type SevenTVPlatformUser struct {
 	User SevenTVUser `json:"user"`
@@ -53,8 +58,9 @@ type SevenTVPlatformUser struct {
 type SevenTVEmoteSet struct {
 	Name   string `json:"name"`
 	Emotes []struct {
-		Name string       `json:"name"`
-		Data SevenTVEmote `json:"data"`
+		Name  string       `json:"name"`
+		Flags int          `json:"flags"`
+		Data  SevenTVEmote `json:"data"`
 	} `json:"emotes"`
 }
 
@@ -69,11 +75,28 @@ func (c SevenTVEmoteSet) Size() uintptr {
 	return size
 }
 
+// Flag of zero-width 7TV emotes.
+const SevenTVEmoteZeroWidth = 1 << 8
+
+// Flag of emotes added to a 7TV emote set as zero-width.
+const SevenTVActiveEmoteZeroWidth = 1 << 0
+
+type SevenTVEmoteOwner struct {
+	ID          string `json:"id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+}
+
 type SevenTVEmote struct {
 	ID       string `json:"id"`
 	Name     string `json:"name"`
 	Animated bool   `json:"animated"`
-	Host     struct {
+	// Bit flags of the emote, see SevenTVEmoteZeroWidth
+	Flags int `json:"flags"`
+	// Bit flags of the emote in its set, see SevenTVActiveEmoteZeroWidth
+	ActiveFlags int                `json:"-"`
+	Owner       *SevenTVEmoteOwner `json:"owner"`
+	Host        struct {
 		Url   string `json:"url,intern"`
 		Files []struct {
 			Name       string `json:"name"`
@@ -125,6 +148,8 @@ func (e *SevenTVEmote) GetImage(scale string, format string) (Image, error) {
 			img.ID = imgID.String()
 			img.Height = f.Height
 			img.Width = f.Width
+			img.Scale = f.Name[:2]
+			img.Format = f.Format
 			img.URL = url.String()
 			return img, nil
 		}
@@ -139,6 +164,8 @@ func (e *SevenTVEmote) GetImage(scale string, format string) (Image, error) {
 				img.ID = imgID.String()
 				img.Height = f.Height
 				img.Width = f.Width
+				img.Scale = f.Name[:2]
+				img.Format = f.Format
 				img.URL = url.String()
 				return img, nil
 			}
@@ -151,22 +178,47 @@ func (e *SevenTVEmote) GetImage(scale string, format string) (Image, error) {
 	img.ID = imgID.String()
 	img.Height = e.Host.Files[0].Height
 	img.Width = e.Host.Files[0].Width
+	img.Scale = e.Host.Files[0].Name[:2]
+	img.Format = e.Host.Files[0].Format
 	img.URL = url.String()
 
 	return img, nil
 }
 
+// AllImages returns an image of every host file of the emote.
+func (e *SevenTVEmote) AllImages() []Image {
+	imgs := make([]Image, 0, len(e.Host.Files))
+	for _, f := range e.Host.Files {
+		imgs = append(imgs, Image{
+			URL:    "https:" + e.Host.Url + "/" + f.Name,
+			Width:  f.Width,
+			Height: f.Height,
+			ID:     e.ID + "+" + f.Name,
+			Scale:  f.Name[:2],
+			Format: f.Format,
+		})
+	}
+	return imgs
+}
+
 func (e *SevenTVEmote) AsEmote() (Emote, error) {
-	img, err := e.GetImage("1x", "WEBP")
+	_, err := e.GetImage("1x", "WEBP")
 	if err != nil {
 		return Emote{}, err
 	}
-	return Emote{
+	em := Emote{
 		ID:        e.ID,
 		Name:      e.Name,
-		Images:    []Image{img},
+		Provider:  ProviderSevenTV,
+		Animated:  e.Animated,
+		ZeroWidth: e.Flags&SevenTVEmoteZeroWidth != 0 || e.ActiveFlags&SevenTVActiveEmoteZeroWidth != 0,
+		Images:    e.AllImages(),
 		Locations: []string{},
-	}, err
+	}
+	if e.Owner != nil {
+		em.Owner = &EmoteOwner{ID: e.Owner.ID, Name: e.Owner.Username, DisplayName: e.Owner.DisplayName}
+	}
+	return em, nil
 }
 
 func (e *SevenTVEmote) Size() uintptr {
@@ -184,7 +236,7 @@ func (e *SevenTVEmote) Size() uintptr {
 	return size
 }
 
-func get7TVEmoteSet(setid string) (SevenTVEmoteSet, error) {
+func get7TVEmoteSet(ctx context.Context, c *apiClient, setid string) (SevenTVEmoteSet, error) {
 	sb := strings.Builder{}
 	set := SevenTVEmoteSet{}
 	err := apiPathOptionTmpl.Execute(&sb, apiPath{
@@ -195,38 +247,7 @@ func get7TVEmoteSet(setid string) (SevenTVEmoteSet, error) {
 	if err != nil {
 		return set, err
 	}
-	//fmt.Println(sb.String())
-
-	req := &http.Request{
-		Method: "GET",
-		URL: &url.URL{
-			Scheme: "https",
-			Host:   sevenTVHost,
-			Path:   sb.String(),
-		},
-		Header: http.Header{},
-	}
-
-	response, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return set, err
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		errorMessage := &jsonError{}
-		err = easyjson.UnmarshalFromReader(response.Body, errorMessage)
-		if err != nil {
-			body, err := io.ReadAll(response.Body)
-			if err != nil {
-				return set, err
-			}
-			return set, errors.New(response.Status + "\n" + string(body))
-		}
-		return set, errors.New(errorMessage.Error.Message)
-	}
-
-	err = easyjson.UnmarshalFromReader(response.Body, &set)
+	err = c.getJSON(ctx, sb.String(), &set)
 	if err != nil {
 		return set, err
 	}
@@ -234,7 +255,7 @@ func get7TVEmoteSet(setid string) (SevenTVEmoteSet, error) {
 	return set, nil
 }
 
-func get7TVUser(platform string, platformID string) (SevenTVUser, error) {
+func get7TVUser(ctx context.Context, c *apiClient, platform string, platformID string) (SevenTVUser, error) {
 	sb := strings.Builder{}
 	u := SevenTVUser{}
 	err := apiPathOptionTmpl.Execute(&sb, apiPath{
@@ -246,48 +267,19 @@ func get7TVUser(platform string, platformID string) (SevenTVUser, error) {
 		return u, err
 	}
 
-	req := &http.Request{
-		Method: "GET",
-		URL: &url.URL{
-			Scheme: "https",
-			Host:   sevenTVHost,
-			Path:   sb.String(),
-		},
-		Header: http.Header{},
-	}
-
-	response, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return u, err
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		errorMessage := &jsonError{}
-		err = easyjson.UnmarshalFromReader(response.Body, errorMessage)
-		if err != nil {
-			body, err := io.ReadAll(response.Body)
-			if err != nil {
-				return u, err
-			}
-			return u, errors.New(response.Status + "\n" + string(body))
-		}
-		return u, errors.New(errorMessage.Error.Message)
-	}
-
 	pu := SevenTVPlatformUser{}
-	err = easyjson.UnmarshalFromReader(response.Body, &pu)
+	err = c.getJSON(ctx, sb.String(), &pu)
 	if err != nil {
 		return u, err
 	}
 	return pu.User, nil
 }
 
-func get7TVUserEmoteSetIDs(platform string, platformID string) ([]string, error) {
+func get7TVUserEmoteSetIDs(ctx context.Context, c *apiClient, platform string, platformID string) ([]string, error) {
 	var u SevenTVUser
 	var err error
 
-	u, err = get7TVUser(platform, platformID)
+	u, err = get7TVUser(ctx, c, platform, platformID)
 	if err != nil {
 		return []string{}, err
 	}
@@ -298,3 +290,80 @@ func get7TVUserEmoteSetIDs(platform string, platformID string) ([]string, error)
 	}
 	return ids, err
 }
+
+type sevenTVProvider struct {
+	opt    *SevenTVOptions
+	client *apiClient
+}
+
+func newSevenTVProvider(opt DownloaderOptions) Provider {
+	return &sevenTVProvider{
+		opt:    opt.SevenTV,
+		client: newAPIClient(opt, ProviderSevenTV, sevenTVBaseURL),
+	}
+}
+
+func (p *sevenTVProvider) Name() string {
+	return ProviderSevenTV
+}
+
+func (p *sevenTVProvider) GlobalEmotes(ctx context.Context) ([]ProviderEmote, error) {
+	s, err := get7TVEmoteSet(ctx, p.client, "global")
+	if err != nil {
+		return nil, err
+	}
+	return s.providerEmotes(), nil
+}
+
+// Every emote set of the user is downloaded concurrently.
+func (p *sevenTVProvider) ChannelEmotes(ctx context.Context) ([]ProviderEmote, error) {
+	if p.opt == nil {
+		return nil, nil
+	}
+	sids, err := get7TVUserEmoteSetIDs(ctx, p.client, p.opt.Platform, p.opt.PlatformID)
+	if err != nil {
+		return nil, err
+	}
+
+	sets := make([]SevenTVEmoteSet, len(sids))
+	errs := make([]error, len(sids))
+
+	var wg sync.WaitGroup
+	for i, sid := range sids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sets[i], errs[i] = get7TVEmoteSet(ctx, p.client, sid)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("%v: failure getting 7TV emote set %s", errs[i], sid)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var pes []ProviderEmote
+	for _, s := range sets {
+		pes = append(pes, s.providerEmotes()...)
+	}
+	return pes, errors.Join(errs...)
+}
+
+func (p *sevenTVProvider) AsEmote(e ProviderEmote) (Emote, error) {
+	se, ok := e.(SevenTVEmote)
+	if !ok {
+		return Emote{}, fmt.Errorf("expected SevenTVEmote, got %T", e)
+	}
+	return se.AsEmote()
+}
+
+// Emotes in a set may be renamed, so the set name replaces the emote name.
+func (c SevenTVEmoteSet) providerEmotes() []ProviderEmote {
+	pes := make([]ProviderEmote, 0, len(c.Emotes))
+	for _, data := range c.Emotes {
+		e := data.Data
+		e.Name = data.Name
+		e.ActiveFlags = data.Flags
+		pes = append(pes, e)
+	}
+	return pes
+}
@@ -0,0 +1,158 @@
+package emodl
+
+import (
+	"context"
+	"image/color"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The v2 cosmetics endpoint lists every cosmetic with the users it is
+// assigned to, unlike the v3 API which only has them per user.
+const sevenTVCosmeticsPath = "/v2/cosmetics?user_identifier=twitch_id"
+
+//easyjson:json
+type SevenTVCosmetics struct {
+	Badges []SevenTVBadge `json:"badges"`
+	Paints []SevenTVPaint `json:"paints"`
+}
+
+type SevenTVBadge struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Tooltip string `json:"tooltip"`
+	// Pairs of scale ("1", "2", "3") and URL
+	URLs [][]string `json:"urls"`
+	// Twitch user IDs
+	Users []string `json:"users"`
+}
+
+// Colors are RGBA packed into a signed integer.
+type SevenTVPaint struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Function string `json:"function"`
+	Color    *int32 `json:"color"`
+	Stops    []struct {
+		At    float64 `json:"at"`
+		Color int32   `json:"color"`
+	} `json:"stops"`
+	Repeat      bool    `json:"repeat"`
+	Angle       float64 `json:"angle"`
+	Shape       string  `json:"shape"`
+	ImageURL    string  `json:"image_url"`
+	DropShadows []struct {
+		XOffset float64 `json:"x_offset"`
+		YOffset float64 `json:"y_offset"`
+		Radius  float64 `json:"radius"`
+		Color   int32   `json:"color"`
+	} `json:"drop_shadows"`
+	// Twitch user IDs
+	Users []string `json:"users"`
+}
+
+func (sp SevenTVPaint) AsPaint() Paint {
+	p := Paint{
+		ID:       sp.ID,
+		Provider: ProviderSevenTV,
+		Name:     sp.Name,
+		Function: sp.Function,
+		Repeat:   sp.Repeat,
+		Angle:    sp.Angle,
+		Shape:    sp.Shape,
+		ImageURL: sp.ImageURL,
+		Users:    sp.Users,
+	}
+	if sp.Color != nil {
+		c := sevenTVColor(*sp.Color)
+		p.Color = &c
+	}
+	for _, s := range sp.Stops {
+		p.Stops = append(p.Stops, PaintStop{At: s.At, Color: sevenTVColor(s.Color)})
+	}
+	for _, s := range sp.DropShadows {
+		p.Shadows = append(p.Shadows, PaintShadow{
+			XOffset: s.XOffset,
+			YOffset: s.YOffset,
+			Radius:  s.Radius,
+			Color:   sevenTVColor(s.Color),
+		})
+	}
+	return p
+}
+
+func sevenTVColor(c int32) color.NRGBA {
+	u := uint32(c)
+	return color.NRGBA{R: uint8(u >> 24), G: uint8(u >> 16), B: uint8(u >> 8), A: uint8(u)}
+}
+
+func get7TVCosmetics(ctx context.Context, c *apiClient) (SevenTVCosmetics, error) {
+	var cosmetics SevenTVCosmetics
+	err := c.getJSON(ctx, sevenTVCosmeticsPath, &cosmetics)
+	if err != nil {
+		return cosmetics, err
+	}
+	return cosmetics, nil
+}
+
+// 7TV badges are assigned to Twitch user IDs.
+func (p *sevenTVProvider) Badges(ctx context.Context) ([]Badge, error) {
+	cosmetics, err := get7TVCosmetics(ctx, p.client)
+	if err != nil {
+		return nil, err
+	}
+
+	badges := make([]Badge, 0, len(cosmetics.Badges))
+	for _, sb := range cosmetics.Badges {
+		b := Badge{
+			ID:       sb.ID,
+			Provider: ProviderSevenTV,
+			Name:     sb.Name,
+			Title:    sb.Tooltip,
+			Users:    sb.Users,
+		}
+		for _, u := range sb.URLs {
+			if len(u) != 2 {
+				continue
+			}
+			scale, err := strconv.Atoi(u[0])
+			if err != nil {
+				continue
+			}
+			b.Images = append(b.Images, badgeImage(sb.ID, u[1], scale))
+		}
+		badges = append(badges, b)
+	}
+	return badges, nil
+}
+
+// The paint selected by the channel user overrides the cosmetics list,
+// which may be out of date.
+func (p *sevenTVProvider) Paints(ctx context.Context) ([]Paint, error) {
+	cosmetics, err := get7TVCosmetics(ctx, p.client)
+	if err != nil {
+		return nil, err
+	}
+	paints := make([]Paint, 0, len(cosmetics.Paints))
+	for _, sp := range cosmetics.Paints {
+		paints = append(paints, sp.AsPaint())
+	}
+
+	if p.opt == nil || strings.ToLower(p.opt.Platform) != "twitch" {
+		return paints, nil
+	}
+	u, err := get7TVUser(ctx, p.client, p.opt.Platform, p.opt.PlatformID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range paints {
+		paints[i].Users = slices.DeleteFunc(slices.Clone(paints[i].Users), func(id string) bool {
+			return id == p.opt.PlatformID
+		})
+		if paints[i].ID == u.Style.PaintID {
+			paints[i].Users = append(paints[i].Users, p.opt.PlatformID)
+		}
+	}
+	return paints, nil
+}
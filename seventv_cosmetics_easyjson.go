@@ -0,0 +1,694 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package emodl
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson90eeec86DecodeGithubComJdavasligilEmodl(in *jlexer.Lexer, out *SevenTVCosmetics) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "badges":
+			if in.IsNull() {
+				in.Skip()
+				out.Badges = nil
+			} else {
+				in.Delim('[')
+				if out.Badges == nil {
+					if !in.IsDelim(']') {
+						out.Badges = make([]SevenTVBadge, 0, 0)
+					} else {
+						out.Badges = []SevenTVBadge{}
+					}
+				} else {
+					out.Badges = (out.Badges)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 SevenTVBadge
+					easyjson90eeec86DecodeGithubComJdavasligilEmodl1(in, &v1)
+					out.Badges = append(out.Badges, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "paints":
+			if in.IsNull() {
+				in.Skip()
+				out.Paints = nil
+			} else {
+				in.Delim('[')
+				if out.Paints == nil {
+					if !in.IsDelim(']') {
+						out.Paints = make([]SevenTVPaint, 0, 0)
+					} else {
+						out.Paints = []SevenTVPaint{}
+					}
+				} else {
+					out.Paints = (out.Paints)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 SevenTVPaint
+					easyjson90eeec86DecodeGithubComJdavasligilEmodl2(in, &v2)
+					out.Paints = append(out.Paints, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson90eeec86EncodeGithubComJdavasligilEmodl(out *jwriter.Writer, in SevenTVCosmetics) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"badges\":"
+		out.RawString(prefix[1:])
+		if in.Badges == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v3, v4 := range in.Badges {
+				if v3 > 0 {
+					out.RawByte(',')
+				}
+				easyjson90eeec86EncodeGithubComJdavasligilEmodl1(out, v4)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"paints\":"
+		out.RawString(prefix)
+		if in.Paints == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Paints {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				easyjson90eeec86EncodeGithubComJdavasligilEmodl2(out, v6)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SevenTVCosmetics) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson90eeec86EncodeGithubComJdavasligilEmodl(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SevenTVCosmetics) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson90eeec86EncodeGithubComJdavasligilEmodl(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SevenTVCosmetics) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson90eeec86DecodeGithubComJdavasligilEmodl(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SevenTVCosmetics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson90eeec86DecodeGithubComJdavasligilEmodl(l, v)
+}
+func easyjson90eeec86DecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *SevenTVPaint) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "function":
+			out.Function = string(in.String())
+		case "color":
+			if in.IsNull() {
+				in.Skip()
+				out.Color = nil
+			} else {
+				if out.Color == nil {
+					out.Color = new(int32)
+				}
+				*out.Color = int32(in.Int32())
+			}
+		case "stops":
+			if in.IsNull() {
+				in.Skip()
+				out.Stops = nil
+			} else {
+				in.Delim('[')
+				if out.Stops == nil {
+					if !in.IsDelim(']') {
+						out.Stops = make([]struct {
+							At    float64 `json:"at"`
+							Color int32   `json:"color"`
+						}, 0, 4)
+					} else {
+						out.Stops = []struct {
+							At    float64 `json:"at"`
+							Color int32   `json:"color"`
+						}{}
+					}
+				} else {
+					out.Stops = (out.Stops)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 struct {
+						At    float64 `json:"at"`
+						Color int32   `json:"color"`
+					}
+					easyjson90eeec86Decode(in, &v7)
+					out.Stops = append(out.Stops, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "repeat":
+			out.Repeat = bool(in.Bool())
+		case "angle":
+			out.Angle = float64(in.Float64())
+		case "shape":
+			out.Shape = string(in.String())
+		case "image_url":
+			out.ImageURL = string(in.String())
+		case "drop_shadows":
+			if in.IsNull() {
+				in.Skip()
+				out.DropShadows = nil
+			} else {
+				in.Delim('[')
+				if out.DropShadows == nil {
+					if !in.IsDelim(']') {
+						out.DropShadows = make([]struct {
+							XOffset float64 `json:"x_offset"`
+							YOffset float64 `json:"y_offset"`
+							Radius  float64 `json:"radius"`
+							Color   int32   `json:"color"`
+						}, 0, 2)
+					} else {
+						out.DropShadows = []struct {
+							XOffset float64 `json:"x_offset"`
+							YOffset float64 `json:"y_offset"`
+							Radius  float64 `json:"radius"`
+							Color   int32   `json:"color"`
+						}{}
+					}
+				} else {
+					out.DropShadows = (out.DropShadows)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v8 struct {
+						XOffset float64 `json:"x_offset"`
+						YOffset float64 `json:"y_offset"`
+						Radius  float64 `json:"radius"`
+						Color   int32   `json:"color"`
+					}
+					easyjson90eeec86Decode1(in, &v8)
+					out.DropShadows = append(out.DropShadows, v8)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+				out.Users = nil
+			} else {
+				in.Delim('[')
+				if out.Users == nil {
+					if !in.IsDelim(']') {
+						out.Users = make([]string, 0, 4)
+					} else {
+						out.Users = []string{}
+					}
+				} else {
+					out.Users = (out.Users)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v9 string
+					v9 = string(in.String())
+					out.Users = append(out.Users, v9)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson90eeec86EncodeGithubComJdavasligilEmodl2(out *jwriter.Writer, in SevenTVPaint) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"function\":"
+		out.RawString(prefix)
+		out.String(string(in.Function))
+	}
+	{
+		const prefix string = ",\"color\":"
+		out.RawString(prefix)
+		if in.Color == nil {
+			out.RawString("null")
+		} else {
+			out.Int32(int32(*in.Color))
+		}
+	}
+	{
+		const prefix string = ",\"stops\":"
+		out.RawString(prefix)
+		if in.Stops == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v10, v11 := range in.Stops {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				easyjson90eeec86Encode(out, v11)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"repeat\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Repeat))
+	}
+	{
+		const prefix string = ",\"angle\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Angle))
+	}
+	{
+		const prefix string = ",\"shape\":"
+		out.RawString(prefix)
+		out.String(string(in.Shape))
+	}
+	{
+		const prefix string = ",\"image_url\":"
+		out.RawString(prefix)
+		out.String(string(in.ImageURL))
+	}
+	{
+		const prefix string = ",\"drop_shadows\":"
+		out.RawString(prefix)
+		if in.DropShadows == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v12, v13 := range in.DropShadows {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				easyjson90eeec86Encode1(out, v13)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix)
+		if in.Users == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.Users {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v15))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson90eeec86Decode1(in *jlexer.Lexer, out *struct {
+	XOffset float64 `json:"x_offset"`
+	YOffset float64 `json:"y_offset"`
+	Radius  float64 `json:"radius"`
+	Color   int32   `json:"color"`
+}) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "x_offset":
+			out.XOffset = float64(in.Float64())
+		case "y_offset":
+			out.YOffset = float64(in.Float64())
+		case "radius":
+			out.Radius = float64(in.Float64())
+		case "color":
+			out.Color = int32(in.Int32())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson90eeec86Encode1(out *jwriter.Writer, in struct {
+	XOffset float64 `json:"x_offset"`
+	YOffset float64 `json:"y_offset"`
+	Radius  float64 `json:"radius"`
+	Color   int32   `json:"color"`
+}) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"x_offset\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.XOffset))
+	}
+	{
+		const prefix string = ",\"y_offset\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.YOffset))
+	}
+	{
+		const prefix string = ",\"radius\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Radius))
+	}
+	{
+		const prefix string = ",\"color\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Color))
+	}
+	out.RawByte('}')
+}
+func easyjson90eeec86Decode(in *jlexer.Lexer, out *struct {
+	At    float64 `json:"at"`
+	Color int32   `json:"color"`
+}) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "at":
+			out.At = float64(in.Float64())
+		case "color":
+			out.Color = int32(in.Int32())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson90eeec86Encode(out *jwriter.Writer, in struct {
+	At    float64 `json:"at"`
+	Color int32   `json:"color"`
+}) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"at\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.At))
+	}
+	{
+		const prefix string = ",\"color\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Color))
+	}
+	out.RawByte('}')
+}
+func easyjson90eeec86DecodeGithubComJdavasligilEmodl1(in *jlexer.Lexer, out *SevenTVBadge) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "tooltip":
+			out.Tooltip = string(in.String())
+		case "urls":
+			if in.IsNull() {
+				in.Skip()
+				out.URLs = nil
+			} else {
+				in.Delim('[')
+				if out.URLs == nil {
+					if !in.IsDelim(']') {
+						out.URLs = make([][]string, 0, 2)
+					} else {
+						out.URLs = [][]string{}
+					}
+				} else {
+					out.URLs = (out.URLs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v16 []string
+					if in.IsNull() {
+						in.Skip()
+						v16 = nil
+					} else {
+						in.Delim('[')
+						if v16 == nil {
+							if !in.IsDelim(']') {
+								v16 = make([]string, 0, 4)
+							} else {
+								v16 = []string{}
+							}
+						} else {
+							v16 = (v16)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v17 string
+							v17 = string(in.String())
+							v16 = append(v16, v17)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					out.URLs = append(out.URLs, v16)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+				out.Users = nil
+			} else {
+				in.Delim('[')
+				if out.Users == nil {
+					if !in.IsDelim(']') {
+						out.Users = make([]string, 0, 4)
+					} else {
+						out.Users = []string{}
+					}
+				} else {
+					out.Users = (out.Users)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v18 string
+					v18 = string(in.String())
+					out.Users = append(out.Users, v18)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson90eeec86EncodeGithubComJdavasligilEmodl1(out *jwriter.Writer, in SevenTVBadge) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"tooltip\":"
+		out.RawString(prefix)
+		out.String(string(in.Tooltip))
+	}
+	{
+		const prefix string = ",\"urls\":"
+		out.RawString(prefix)
+		if in.URLs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v19, v20 := range in.URLs {
+				if v19 > 0 {
+					out.RawByte(',')
+				}
+				if v20 == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v21, v22 := range v20 {
+						if v21 > 0 {
+							out.RawByte(',')
+						}
+						out.String(string(v22))
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix)
+		if in.Users == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v23, v24 := range in.Users {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v24))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
@@ -38,6 +38,8 @@ func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl(in *jlexer.Lexer, out *Seve
 		switch key {
 		case "id":
 			out.ID = string(in.String())
+		case "style":
+			easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl1(in, &out.Style)
 		case "emote_sets":
 			if in.IsNull() {
 				in.Skip()
@@ -86,6 +88,11 @@ func easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl(out *jwriter.Writer, in Sev
 		out.RawString(prefix[1:])
 		out.String(string(in.ID))
 	}
+	{
+		const prefix string = ",\"style\":"
+		out.RawString(prefix)
+		easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl1(out, in.Style)
+	}
 	{
 		const prefix string = ",\"emote_sets\":"
 		out.RawString(prefix)
@@ -174,7 +181,63 @@ func easyjson2d7cdb3fEncode(out *jwriter.Writer, in struct {
 	}
 	out.RawByte('}')
 }
-func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl1(in *jlexer.Lexer, out *SevenTVPlatformUser) {
+func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl1(in *jlexer.Lexer, out *SevenTVUserStyle) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "color":
+			out.Color = int32(in.Int32())
+		case "paint_id":
+			out.PaintID = string(in.String())
+		case "badge_id":
+			out.BadgeID = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl1(out *jwriter.Writer, in SevenTVUserStyle) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"color\":"
+		out.RawString(prefix[1:])
+		out.Int32(int32(in.Color))
+	}
+	{
+		const prefix string = ",\"paint_id\":"
+		out.RawString(prefix)
+		out.String(string(in.PaintID))
+	}
+	{
+		const prefix string = ",\"badge_id\":"
+		out.RawString(prefix)
+		out.String(string(in.BadgeID))
+	}
+	out.RawByte('}')
+}
+func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *SevenTVPlatformUser) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -205,7 +268,7 @@ func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl1(in *jlexer.Lexer, out *Sev
 		in.Consumed()
 	}
 }
-func easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl1(out *jwriter.Writer, in SevenTVPlatformUser) {
+func easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl2(out *jwriter.Writer, in SevenTVPlatformUser) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -220,27 +283,27 @@ func easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl1(out *jwriter.Writer, in Se
 // MarshalJSON supports json.Marshaler interface
 func (v SevenTVPlatformUser) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl1(&w, v)
+	easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v SevenTVPlatformUser) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl1(w, v)
+	easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *SevenTVPlatformUser) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl1(&r, v)
+	easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *SevenTVPlatformUser) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl1(l, v)
+	easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl2(l, v)
 }
-func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *SevenTVEmoteSet) {
+func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl3(in *jlexer.Lexer, out *SevenTVEmoteSet) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -270,13 +333,15 @@ func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *Sev
 				if out.Emotes == nil {
 					if !in.IsDelim(']') {
 						out.Emotes = make([]struct {
-							Name string       `json:"name"`
-							Data SevenTVEmote `json:"data"`
+							Name  string       `json:"name"`
+							Flags int          `json:"flags"`
+							Data  SevenTVEmote `json:"data"`
 						}, 0, 0)
 					} else {
 						out.Emotes = []struct {
-							Name string       `json:"name"`
-							Data SevenTVEmote `json:"data"`
+							Name  string       `json:"name"`
+							Flags int          `json:"flags"`
+							Data  SevenTVEmote `json:"data"`
 						}{}
 					}
 				} else {
@@ -284,8 +349,9 @@ func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *Sev
 				}
 				for !in.IsDelim(']') {
 					var v4 struct {
-						Name string       `json:"name"`
-						Data SevenTVEmote `json:"data"`
+						Name  string       `json:"name"`
+						Flags int          `json:"flags"`
+						Data  SevenTVEmote `json:"data"`
 					}
 					easyjson2d7cdb3fDecode1(in, &v4)
 					out.Emotes = append(out.Emotes, v4)
@@ -303,7 +369,7 @@ func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *Sev
 		in.Consumed()
 	}
 }
-func easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl2(out *jwriter.Writer, in SevenTVEmoteSet) {
+func easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl3(out *jwriter.Writer, in SevenTVEmoteSet) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -334,29 +400,30 @@ func easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl2(out *jwriter.Writer, in Se
 // MarshalJSON supports json.Marshaler interface
 func (v SevenTVEmoteSet) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl2(&w, v)
+	easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v SevenTVEmoteSet) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl2(w, v)
+	easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *SevenTVEmoteSet) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl2(&r, v)
+	easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *SevenTVEmoteSet) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl2(l, v)
+	easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl3(l, v)
 }
 func easyjson2d7cdb3fDecode1(in *jlexer.Lexer, out *struct {
-	Name string       `json:"name"`
-	Data SevenTVEmote `json:"data"`
+	Name  string       `json:"name"`
+	Flags int          `json:"flags"`
+	Data  SevenTVEmote `json:"data"`
 }) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
@@ -378,8 +445,10 @@ func easyjson2d7cdb3fDecode1(in *jlexer.Lexer, out *struct {
 		switch key {
 		case "name":
 			out.Name = string(in.String())
+		case "flags":
+			out.Flags = int(in.Int())
 		case "data":
-			easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl3(in, &out.Data)
+			easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl4(in, &out.Data)
 		default:
 			in.SkipRecursive()
 		}
@@ -391,8 +460,9 @@ func easyjson2d7cdb3fDecode1(in *jlexer.Lexer, out *struct {
 	}
 }
 func easyjson2d7cdb3fEncode1(out *jwriter.Writer, in struct {
-	Name string       `json:"name"`
-	Data SevenTVEmote `json:"data"`
+	Name  string       `json:"name"`
+	Flags int          `json:"flags"`
+	Data  SevenTVEmote `json:"data"`
 }) {
 	out.RawByte('{')
 	first := true
@@ -402,14 +472,19 @@ func easyjson2d7cdb3fEncode1(out *jwriter.Writer, in struct {
 		out.RawString(prefix[1:])
 		out.String(string(in.Name))
 	}
+	{
+		const prefix string = ",\"flags\":"
+		out.RawString(prefix)
+		out.Int(int(in.Flags))
+	}
 	{
 		const prefix string = ",\"data\":"
 		out.RawString(prefix)
-		easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl3(out, in.Data)
+		easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl4(out, in.Data)
 	}
 	out.RawByte('}')
 }
-func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl3(in *jlexer.Lexer, out *SevenTVEmote) {
+func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl4(in *jlexer.Lexer, out *SevenTVEmote) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -434,6 +509,18 @@ func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl3(in *jlexer.Lexer, out *Sev
 			out.Name = string(in.String())
 		case "animated":
 			out.Animated = bool(in.Bool())
+		case "flags":
+			out.Flags = int(in.Int())
+		case "owner":
+			if in.IsNull() {
+				in.Skip()
+				out.Owner = nil
+			} else {
+				if out.Owner == nil {
+					out.Owner = new(SevenTVEmoteOwner)
+				}
+				easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl5(in, out.Owner)
+			}
 		case "host":
 			easyjson2d7cdb3fDecode2(in, &out.Host)
 		default:
@@ -446,7 +533,7 @@ func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl3(in *jlexer.Lexer, out *Sev
 		in.Consumed()
 	}
 }
-func easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl3(out *jwriter.Writer, in SevenTVEmote) {
+func easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl4(out *jwriter.Writer, in SevenTVEmote) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -465,6 +552,20 @@ func easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl3(out *jwriter.Writer, in Se
 		out.RawString(prefix)
 		out.Bool(bool(in.Animated))
 	}
+	{
+		const prefix string = ",\"flags\":"
+		out.RawString(prefix)
+		out.Int(int(in.Flags))
+	}
+	{
+		const prefix string = ",\"owner\":"
+		out.RawString(prefix)
+		if in.Owner == nil {
+			out.RawString("null")
+		} else {
+			easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl5(out, *in.Owner)
+		}
+	}
 	{
 		const prefix string = ",\"host\":"
 		out.RawString(prefix)
@@ -699,3 +800,59 @@ func easyjson2d7cdb3fEncode3(out *jwriter.Writer, in struct {
 	}
 	out.RawByte('}')
 }
+func easyjson2d7cdb3fDecodeGithubComJdavasligilEmodl5(in *jlexer.Lexer, out *SevenTVEmoteOwner) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "username":
+			out.Username = string(in.String())
+		case "display_name":
+			out.DisplayName = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2d7cdb3fEncodeGithubComJdavasligilEmodl5(out *jwriter.Writer, in SevenTVEmoteOwner) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"username\":"
+		out.RawString(prefix)
+		out.String(string(in.Username))
+	}
+	{
+		const prefix string = ",\"display_name\":"
+		out.RawString(prefix)
+		out.String(string(in.DisplayName))
+	}
+	out.RawByte('}')
+}
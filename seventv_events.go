@@ -0,0 +1,350 @@
+package emodl
+
+// DOCUMENTATION
+// https://github.com/SevenTV/EventAPI?tab=readme-ov-file#7tv-eventapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mailru/easyjson"
+)
+
+const sevenTVEventURL = "wss://events.7tv.io/v3"
+
+// Opcodes of the 7TV EventAPI.
+const (
+	sevenTVOpDispatch    = 0
+	sevenTVOpHello       = 1
+	sevenTVOpHeartbeat   = 2
+	sevenTVOpReconnect   = 4
+	sevenTVOpAck         = 5
+	sevenTVOpError       = 6
+	sevenTVOpEndOfStream = 7
+	sevenTVOpResume      = 34
+	sevenTVOpSubscribe   = 35
+)
+
+const sevenTVEmoteSetUpdate = "emote_set.update"
+
+// Heartbeats missed before a connection is considered dead.
+const sevenTVMissedHeartbeats = 3
+
+//easyjson:json
+type sevenTVEventMessage struct {
+	Op int                 `json:"op"`
+	T  int64               `json:"t,omitempty"`
+	D  easyjson.RawMessage `json:"d"`
+}
+
+//easyjson:json
+type sevenTVHello struct {
+	HeartbeatInterval int64  `json:"heartbeat_interval"`
+	SessionID         string `json:"session_id"`
+}
+
+//easyjson:json
+type sevenTVAck struct {
+	Command string `json:"command"`
+	Data    struct {
+		Success bool `json:"success"`
+	} `json:"data"`
+}
+
+//easyjson:json
+type sevenTVEndOfStream struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+//easyjson:json
+type sevenTVResume struct {
+	SessionID string `json:"session_id"`
+}
+
+//easyjson:json
+type sevenTVSubscribe struct {
+	Type      string            `json:"type"`
+	Condition map[string]string `json:"condition"`
+}
+
+//easyjson:json
+type sevenTVDispatch struct {
+	Type string           `json:"type"`
+	Body sevenTVChangeMap `json:"body"`
+}
+
+// Changes to an object such as an emote set. Values of the "emotes" key are
+// active emotes of the set.
+type sevenTVChangeMap struct {
+	ID      string               `json:"id"`
+	Pushed  []sevenTVChangeField `json:"pushed"`
+	Pulled  []sevenTVChangeField `json:"pulled"`
+	Updated []sevenTVChangeField `json:"updated"`
+}
+
+type sevenTVChangeField struct {
+	Key      string              `json:"key"`
+	Value    easyjson.RawMessage `json:"value"`
+	OldValue easyjson.RawMessage `json:"old_value"`
+}
+
+//easyjson:json
+type sevenTVActiveEmote struct {
+	ID    string        `json:"id"`
+	Name  string        `json:"name"`
+	Flags int           `json:"flags"`
+	Data  *SevenTVEmote `json:"data"`
+}
+
+// SevenTVEventClient keeps the 7TV channel emotes of a Downloader up to
+// date with the emote_set.update events of the 7TV EventAPI.
+//
+// Changes missed while disconnected are only applied if the session is
+// resumed. Running Watch alongside catches up on anything else.
+type SevenTVEventClient struct {
+	ed  *Downloader
+	opt LiveOptions
+
+	sessionID string
+}
+
+// NewSevenTVEventClient returns a client for the emote sets of the 7TV user
+// configured in the options of ed. Load ed first so that changes apply to
+// the loaded emotes.
+func NewSevenTVEventClient(ed *Downloader, opt LiveOptions) *SevenTVEventClient {
+	opt.setDefaults(sevenTVEventURL)
+	return &SevenTVEventClient{ed: ed, opt: opt}
+}
+
+// Run subscribes to the emote sets of the user and applies their changes
+// until ctx is done. Dropped connections are resumed, or subscribed again
+// if the session expired. Always returns the error of ctx.
+func (c *SevenTVEventClient) Run(ctx context.Context) error {
+	return runLive(ctx, c.opt, c.connect)
+}
+
+// Start runs the client in a new goroutine and returns a channel receiving
+// the changes of every event. The channel is closed once ctx is done.
+//
+// The OnChange option is replaced. Events wait for the changes to be
+// received.
+func (c *SevenTVEventClient) Start(ctx context.Context) <-chan []EmoteDiff {
+	return startLive(ctx, &c.opt, c.Run)
+}
+
+func (c *SevenTVEventClient) connect(ctx context.Context) (bool, bool, error) {
+	conn, closeConn, err := dialLive(ctx, c.opt, c.ed.Options.UserAgent)
+	if err != nil {
+		return false, false, fmt.Errorf("emodl: %v: failure connecting to 7TV EventAPI", err)
+	}
+	defer closeConn()
+
+	var hello sevenTVHello
+	helloDeadline := time.Now().Add(downloadTimeout * time.Second)
+	if err := c.read(conn, helloDeadline, sevenTVOpHello, &hello); err != nil {
+		return false, false, err
+	}
+	resumed := c.sessionID
+	c.sessionID = hello.SessionID
+	if resumed != "" {
+		err = c.write(conn, sevenTVOpResume, &sevenTVResume{SessionID: resumed})
+	} else {
+		err = c.subscribe(ctx, conn)
+	}
+	if err != nil {
+		return false, false, err
+	}
+
+	timeout := sevenTVMissedHeartbeats * time.Duration(hello.HeartbeatInterval) * time.Millisecond
+	for received := false; ; received = true {
+		var deadline time.Time
+		if timeout > 0 {
+			deadline = time.Now().Add(timeout)
+		}
+		var msg sevenTVEventMessage
+		if err := c.read(conn, deadline, -1, &msg); err != nil {
+			return received, false, err
+		}
+
+		switch msg.Op {
+		case sevenTVOpDispatch:
+			var d sevenTVDispatch
+			if err := easyjson.Unmarshal(msg.D, &d); err != nil {
+				return true, false, fmt.Errorf("emodl: %v: failure decoding 7TV dispatch", err)
+			}
+			if d.Type == sevenTVEmoteSetUpdate {
+				c.apply(d.Body)
+			}
+		case sevenTVOpAck:
+			var ack sevenTVAck
+			if err := easyjson.Unmarshal(msg.D, &ack); err != nil {
+				return true, false, fmt.Errorf("emodl: %v: failure decoding 7TV ack", err)
+			}
+			if ack.Command == "RESUME" && !ack.Data.Success {
+				if err := c.subscribe(ctx, conn); err != nil {
+					return true, false, err
+				}
+			}
+		case sevenTVOpReconnect:
+			return true, true, nil
+		case sevenTVOpError:
+			return true, false, fmt.Errorf("emodl: 7TV EventAPI error: %s", msg.D)
+		case sevenTVOpEndOfStream:
+			var eos sevenTVEndOfStream
+			easyjson.Unmarshal(msg.D, &eos)
+			return true, false, fmt.Errorf("emodl: 7TV EventAPI closed the connection: %d %s", eos.Code, eos.Message)
+		}
+	}
+}
+
+// Subscribes to every emote set of the user.
+func (c *SevenTVEventClient) subscribe(ctx context.Context, conn *websocket.Conn) error {
+	p, ok := c.provider()
+	if !ok || p.opt == nil {
+		return errors.New("emodl: no 7TV channel configured")
+	}
+	sids, err := get7TVUserEmoteSetIDs(ctx, p.client, p.opt.Platform, p.opt.PlatformID)
+	if err != nil {
+		return fmt.Errorf("emodl: %v: failure getting 7TV emote sets", err)
+	}
+	for _, sid := range sids {
+		err := c.write(conn, sevenTVOpSubscribe, &sevenTVSubscribe{
+			Type:      sevenTVEmoteSetUpdate,
+			Condition: map[string]string{"object_id": sid},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *SevenTVEventClient) provider() (*sevenTVProvider, bool) {
+	for _, p := range c.ed.providers {
+		if p, ok := p.(*sevenTVProvider); ok {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Applies the emote changes of an emote set to the channel emotes.
+func (c *SevenTVEventClient) apply(cm sevenTVChangeMap) {
+	var patch sourcePatch
+	var errs []error
+	decode := func(raw easyjson.RawMessage) (sevenTVActiveEmote, bool) {
+		var ae sevenTVActiveEmote
+		if err := easyjson.Unmarshal(raw, &ae); err != nil {
+			errs = append(errs, fmt.Errorf("emodl: %v: failure decoding 7TV emote of set %s", err, cm.ID))
+			return ae, false
+		}
+		return ae, true
+	}
+
+	// Other fields of the set, such as its name, are not emotes
+	for _, f := range cm.Pulled {
+		if f.Key != "emotes" {
+			continue
+		}
+		if ae, ok := decode(f.OldValue); ok {
+			patch.remove = append(patch.remove, ae.ID)
+		}
+	}
+	for _, f := range cm.Pushed {
+		if f.Key != "emotes" {
+			continue
+		}
+		ae, ok := decode(f.Value)
+		if !ok {
+			continue
+		}
+		if ae.Data == nil {
+			errs = append(errs, fmt.Errorf("emodl: 7TV emote %s of set %s has no data", ae.ID, cm.ID))
+			continue
+		}
+		e := *ae.Data
+		e.Name = ae.Name
+		e.ActiveFlags = ae.Flags
+		patch.add = append(patch.add, e)
+	}
+	for _, f := range cm.Updated {
+		if f.Key != "emotes" {
+			continue
+		}
+		ae, ok := decode(f.Value)
+		if !ok {
+			continue
+		}
+		var e SevenTVEmote
+		if ae.Data != nil {
+			e = *ae.Data
+		} else if e, ok = c.loaded(ae.ID); !ok {
+			errs = append(errs, fmt.Errorf("emodl: 7TV emote %s of set %s has no data", ae.ID, cm.ID))
+			continue
+		}
+		e.Name = ae.Name
+		e.ActiveFlags = ae.Flags
+		patch.update = append(patch.update, e)
+	}
+
+	d, err := c.ed.applyPatch(ProviderSevenTV, true, patch)
+	errs = append(errs, err)
+	if err := errors.Join(errs...); err != nil && c.opt.OnError != nil {
+		c.opt.OnError(err)
+	}
+	if !d.Empty() && c.opt.OnChange != nil {
+		c.opt.OnChange([]EmoteDiff{d})
+	}
+}
+
+// Returns a loaded channel emote by ID.
+func (c *SevenTVEventClient) loaded(id string) (SevenTVEmote, bool) {
+	c.ed.mu.RLock()
+	defer c.ed.mu.RUnlock()
+	for _, pe := range c.ed.sources[sourceKey{provider: ProviderSevenTV, channel: true}].native {
+		if e, ok := pe.(SevenTVEmote); ok && e.ID == id {
+			return e, true
+		}
+	}
+	return SevenTVEmote{}, false
+}
+
+// Reads a message, or the payload of a message with the given opcode if op
+// is not negative. A zero deadline waits forever.
+func (c *SevenTVEventClient) read(conn *websocket.Conn, deadline time.Time, op int, v easyjson.Unmarshaler) error {
+	conn.SetReadDeadline(deadline)
+	_, b, err := conn.ReadMessage()
+	if err != nil {
+		return fmt.Errorf("emodl: %v: failure reading from 7TV EventAPI", err)
+	}
+	if op < 0 {
+		return easyjson.Unmarshal(b, v)
+	}
+	var msg sevenTVEventMessage
+	if err := easyjson.Unmarshal(b, &msg); err != nil {
+		return err
+	}
+	if msg.Op != op {
+		return fmt.Errorf("emodl: 7TV EventAPI sent opcode %d, expected %d", msg.Op, op)
+	}
+	return easyjson.Unmarshal(msg.D, v)
+}
+
+func (c *SevenTVEventClient) write(conn *websocket.Conn, op int, v easyjson.Marshaler) error {
+	d, err := easyjson.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b, err := easyjson.Marshal(&sevenTVEventMessage{Op: op, D: d})
+	if err != nil {
+		return err
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, b); err != nil {
+		return fmt.Errorf("emodl: %v: failure writing to 7TV EventAPI", err)
+	}
+	return nil
+}
@@ -0,0 +1,1292 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package emodl
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson15d3c5d7DecodeGithubComJdavasligilEmodl(in *jlexer.Lexer, out *sevenTVSubscribe) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "condition":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Condition = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v1 string
+					v1 = string(in.String())
+					(out.Condition)[key] = v1
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7EncodeGithubComJdavasligilEmodl(out *jwriter.Writer, in sevenTVSubscribe) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"condition\":"
+		out.RawString(prefix)
+		if in.Condition == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v2First := true
+			for v2Name, v2Value := range in.Condition {
+				if v2First {
+					v2First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v2Name))
+				out.RawByte(':')
+				out.String(string(v2Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v sevenTVSubscribe) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v sevenTVSubscribe) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *sevenTVSubscribe) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *sevenTVSubscribe) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl(l, v)
+}
+func easyjson15d3c5d7DecodeGithubComJdavasligilEmodl1(in *jlexer.Lexer, out *sevenTVResume) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "session_id":
+			out.SessionID = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7EncodeGithubComJdavasligilEmodl1(out *jwriter.Writer, in sevenTVResume) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"session_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.SessionID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v sevenTVResume) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v sevenTVResume) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *sevenTVResume) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *sevenTVResume) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl1(l, v)
+}
+func easyjson15d3c5d7DecodeGithubComJdavasligilEmodl2(in *jlexer.Lexer, out *sevenTVHello) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "heartbeat_interval":
+			out.HeartbeatInterval = int64(in.Int64())
+		case "session_id":
+			out.SessionID = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7EncodeGithubComJdavasligilEmodl2(out *jwriter.Writer, in sevenTVHello) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"heartbeat_interval\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.HeartbeatInterval))
+	}
+	{
+		const prefix string = ",\"session_id\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v sevenTVHello) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v sevenTVHello) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *sevenTVHello) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *sevenTVHello) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl2(l, v)
+}
+func easyjson15d3c5d7DecodeGithubComJdavasligilEmodl3(in *jlexer.Lexer, out *sevenTVEventMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "op":
+			out.Op = int(in.Int())
+		case "t":
+			out.T = int64(in.Int64())
+		case "d":
+			(out.D).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7EncodeGithubComJdavasligilEmodl3(out *jwriter.Writer, in sevenTVEventMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"op\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Op))
+	}
+	if in.T != 0 {
+		const prefix string = ",\"t\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.T))
+	}
+	{
+		const prefix string = ",\"d\":"
+		out.RawString(prefix)
+		(in.D).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v sevenTVEventMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v sevenTVEventMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *sevenTVEventMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *sevenTVEventMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl3(l, v)
+}
+func easyjson15d3c5d7DecodeGithubComJdavasligilEmodl4(in *jlexer.Lexer, out *sevenTVEndOfStream) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "code":
+			out.Code = int(in.Int())
+		case "message":
+			out.Message = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7EncodeGithubComJdavasligilEmodl4(out *jwriter.Writer, in sevenTVEndOfStream) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Code))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v sevenTVEndOfStream) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v sevenTVEndOfStream) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *sevenTVEndOfStream) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *sevenTVEndOfStream) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl4(l, v)
+}
+func easyjson15d3c5d7DecodeGithubComJdavasligilEmodl5(in *jlexer.Lexer, out *sevenTVDispatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "body":
+			easyjson15d3c5d7DecodeGithubComJdavasligilEmodl6(in, &out.Body)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7EncodeGithubComJdavasligilEmodl5(out *jwriter.Writer, in sevenTVDispatch) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		easyjson15d3c5d7EncodeGithubComJdavasligilEmodl6(out, in.Body)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v sevenTVDispatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v sevenTVDispatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *sevenTVDispatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *sevenTVDispatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl5(l, v)
+}
+func easyjson15d3c5d7DecodeGithubComJdavasligilEmodl6(in *jlexer.Lexer, out *sevenTVChangeMap) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "pushed":
+			if in.IsNull() {
+				in.Skip()
+				out.Pushed = nil
+			} else {
+				in.Delim('[')
+				if out.Pushed == nil {
+					if !in.IsDelim(']') {
+						out.Pushed = make([]sevenTVChangeField, 0, 1)
+					} else {
+						out.Pushed = []sevenTVChangeField{}
+					}
+				} else {
+					out.Pushed = (out.Pushed)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 sevenTVChangeField
+					easyjson15d3c5d7DecodeGithubComJdavasligilEmodl7(in, &v3)
+					out.Pushed = append(out.Pushed, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "pulled":
+			if in.IsNull() {
+				in.Skip()
+				out.Pulled = nil
+			} else {
+				in.Delim('[')
+				if out.Pulled == nil {
+					if !in.IsDelim(']') {
+						out.Pulled = make([]sevenTVChangeField, 0, 1)
+					} else {
+						out.Pulled = []sevenTVChangeField{}
+					}
+				} else {
+					out.Pulled = (out.Pulled)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 sevenTVChangeField
+					easyjson15d3c5d7DecodeGithubComJdavasligilEmodl7(in, &v4)
+					out.Pulled = append(out.Pulled, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "updated":
+			if in.IsNull() {
+				in.Skip()
+				out.Updated = nil
+			} else {
+				in.Delim('[')
+				if out.Updated == nil {
+					if !in.IsDelim(']') {
+						out.Updated = make([]sevenTVChangeField, 0, 1)
+					} else {
+						out.Updated = []sevenTVChangeField{}
+					}
+				} else {
+					out.Updated = (out.Updated)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 sevenTVChangeField
+					easyjson15d3c5d7DecodeGithubComJdavasligilEmodl7(in, &v5)
+					out.Updated = append(out.Updated, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7EncodeGithubComJdavasligilEmodl6(out *jwriter.Writer, in sevenTVChangeMap) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"pushed\":"
+		out.RawString(prefix)
+		if in.Pushed == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v6, v7 := range in.Pushed {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				easyjson15d3c5d7EncodeGithubComJdavasligilEmodl7(out, v7)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"pulled\":"
+		out.RawString(prefix)
+		if in.Pulled == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Pulled {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				easyjson15d3c5d7EncodeGithubComJdavasligilEmodl7(out, v9)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"updated\":"
+		out.RawString(prefix)
+		if in.Updated == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v10, v11 := range in.Updated {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				easyjson15d3c5d7EncodeGithubComJdavasligilEmodl7(out, v11)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson15d3c5d7DecodeGithubComJdavasligilEmodl7(in *jlexer.Lexer, out *sevenTVChangeField) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "key":
+			out.Key = string(in.String())
+		case "value":
+			(out.Value).UnmarshalEasyJSON(in)
+		case "old_value":
+			(out.OldValue).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7EncodeGithubComJdavasligilEmodl7(out *jwriter.Writer, in sevenTVChangeField) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"key\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Key))
+	}
+	{
+		const prefix string = ",\"value\":"
+		out.RawString(prefix)
+		(in.Value).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"old_value\":"
+		out.RawString(prefix)
+		(in.OldValue).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+func easyjson15d3c5d7DecodeGithubComJdavasligilEmodl8(in *jlexer.Lexer, out *sevenTVActiveEmote) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "flags":
+			out.Flags = int(in.Int())
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+				out.Data = nil
+			} else {
+				if out.Data == nil {
+					out.Data = new(SevenTVEmote)
+				}
+				easyjson15d3c5d7DecodeGithubComJdavasligilEmodl9(in, out.Data)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7EncodeGithubComJdavasligilEmodl8(out *jwriter.Writer, in sevenTVActiveEmote) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"flags\":"
+		out.RawString(prefix)
+		out.Int(int(in.Flags))
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		if in.Data == nil {
+			out.RawString("null")
+		} else {
+			easyjson15d3c5d7EncodeGithubComJdavasligilEmodl9(out, *in.Data)
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v sevenTVActiveEmote) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v sevenTVActiveEmote) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *sevenTVActiveEmote) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *sevenTVActiveEmote) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl8(l, v)
+}
+func easyjson15d3c5d7DecodeGithubComJdavasligilEmodl9(in *jlexer.Lexer, out *SevenTVEmote) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "animated":
+			out.Animated = bool(in.Bool())
+		case "flags":
+			out.Flags = int(in.Int())
+		case "owner":
+			if in.IsNull() {
+				in.Skip()
+				out.Owner = nil
+			} else {
+				if out.Owner == nil {
+					out.Owner = new(SevenTVEmoteOwner)
+				}
+				easyjson15d3c5d7DecodeGithubComJdavasligilEmodl10(in, out.Owner)
+			}
+		case "host":
+			easyjson15d3c5d7Decode(in, &out.Host)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7EncodeGithubComJdavasligilEmodl9(out *jwriter.Writer, in SevenTVEmote) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"animated\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Animated))
+	}
+	{
+		const prefix string = ",\"flags\":"
+		out.RawString(prefix)
+		out.Int(int(in.Flags))
+	}
+	{
+		const prefix string = ",\"owner\":"
+		out.RawString(prefix)
+		if in.Owner == nil {
+			out.RawString("null")
+		} else {
+			easyjson15d3c5d7EncodeGithubComJdavasligilEmodl10(out, *in.Owner)
+		}
+	}
+	{
+		const prefix string = ",\"host\":"
+		out.RawString(prefix)
+		easyjson15d3c5d7Encode(out, in.Host)
+	}
+	out.RawByte('}')
+}
+func easyjson15d3c5d7Decode(in *jlexer.Lexer, out *struct {
+	Url   string `json:"url,intern"`
+	Files []struct {
+		Name       string `json:"name"`
+		StaticName string `json:"static_name"`
+		Width      int    `json:"width"`
+		Height     int    `json:"height"`
+		FrameCount int    `json:"frame_count"`
+		Size       uint32 `json:"size"`
+		Format     string `json:"format"`
+	} `json:"files"`
+}) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.Url = string(in.StringIntern())
+		case "files":
+			if in.IsNull() {
+				in.Skip()
+				out.Files = nil
+			} else {
+				in.Delim('[')
+				if out.Files == nil {
+					if !in.IsDelim(']') {
+						out.Files = make([]struct {
+							Name       string `json:"name"`
+							StaticName string `json:"static_name"`
+							Width      int    `json:"width"`
+							Height     int    `json:"height"`
+							FrameCount int    `json:"frame_count"`
+							Size       uint32 `json:"size"`
+							Format     string `json:"format"`
+						}, 0, 0)
+					} else {
+						out.Files = []struct {
+							Name       string `json:"name"`
+							StaticName string `json:"static_name"`
+							Width      int    `json:"width"`
+							Height     int    `json:"height"`
+							FrameCount int    `json:"frame_count"`
+							Size       uint32 `json:"size"`
+							Format     string `json:"format"`
+						}{}
+					}
+				} else {
+					out.Files = (out.Files)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v12 struct {
+						Name       string `json:"name"`
+						StaticName string `json:"static_name"`
+						Width      int    `json:"width"`
+						Height     int    `json:"height"`
+						FrameCount int    `json:"frame_count"`
+						Size       uint32 `json:"size"`
+						Format     string `json:"format"`
+					}
+					easyjson15d3c5d7Decode1(in, &v12)
+					out.Files = append(out.Files, v12)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7Encode(out *jwriter.Writer, in struct {
+	Url   string `json:"url,intern"`
+	Files []struct {
+		Name       string `json:"name"`
+		StaticName string `json:"static_name"`
+		Width      int    `json:"width"`
+		Height     int    `json:"height"`
+		FrameCount int    `json:"frame_count"`
+		Size       uint32 `json:"size"`
+		Format     string `json:"format"`
+	} `json:"files"`
+}) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Url))
+	}
+	{
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		if in.Files == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v13, v14 := range in.Files {
+				if v13 > 0 {
+					out.RawByte(',')
+				}
+				easyjson15d3c5d7Encode1(out, v14)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson15d3c5d7Decode1(in *jlexer.Lexer, out *struct {
+	Name       string `json:"name"`
+	StaticName string `json:"static_name"`
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	FrameCount int    `json:"frame_count"`
+	Size       uint32 `json:"size"`
+	Format     string `json:"format"`
+}) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "static_name":
+			out.StaticName = string(in.String())
+		case "width":
+			out.Width = int(in.Int())
+		case "height":
+			out.Height = int(in.Int())
+		case "frame_count":
+			out.FrameCount = int(in.Int())
+		case "size":
+			out.Size = uint32(in.Uint32())
+		case "format":
+			out.Format = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7Encode1(out *jwriter.Writer, in struct {
+	Name       string `json:"name"`
+	StaticName string `json:"static_name"`
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	FrameCount int    `json:"frame_count"`
+	Size       uint32 `json:"size"`
+	Format     string `json:"format"`
+}) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"static_name\":"
+		out.RawString(prefix)
+		out.String(string(in.StaticName))
+	}
+	{
+		const prefix string = ",\"width\":"
+		out.RawString(prefix)
+		out.Int(int(in.Width))
+	}
+	{
+		const prefix string = ",\"height\":"
+		out.RawString(prefix)
+		out.Int(int(in.Height))
+	}
+	{
+		const prefix string = ",\"frame_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.FrameCount))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.Size))
+	}
+	{
+		const prefix string = ",\"format\":"
+		out.RawString(prefix)
+		out.String(string(in.Format))
+	}
+	out.RawByte('}')
+}
+func easyjson15d3c5d7DecodeGithubComJdavasligilEmodl10(in *jlexer.Lexer, out *SevenTVEmoteOwner) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "username":
+			out.Username = string(in.String())
+		case "display_name":
+			out.DisplayName = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7EncodeGithubComJdavasligilEmodl10(out *jwriter.Writer, in SevenTVEmoteOwner) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"username\":"
+		out.RawString(prefix)
+		out.String(string(in.Username))
+	}
+	{
+		const prefix string = ",\"display_name\":"
+		out.RawString(prefix)
+		out.String(string(in.DisplayName))
+	}
+	out.RawByte('}')
+}
+func easyjson15d3c5d7DecodeGithubComJdavasligilEmodl11(in *jlexer.Lexer, out *sevenTVAck) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "command":
+			out.Command = string(in.String())
+		case "data":
+			easyjson15d3c5d7Decode2(in, &out.Data)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7EncodeGithubComJdavasligilEmodl11(out *jwriter.Writer, in sevenTVAck) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"command\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Command))
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		easyjson15d3c5d7Encode2(out, in.Data)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v sevenTVAck) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v sevenTVAck) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson15d3c5d7EncodeGithubComJdavasligilEmodl11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *sevenTVAck) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *sevenTVAck) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson15d3c5d7DecodeGithubComJdavasligilEmodl11(l, v)
+}
+func easyjson15d3c5d7Decode2(in *jlexer.Lexer, out *struct {
+	Success bool `json:"success"`
+}) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "success":
+			out.Success = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson15d3c5d7Encode2(out *jwriter.Writer, in struct {
+	Success bool `json:"success"`
+}) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"success\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Success))
+	}
+	out.RawByte('}')
+}
@@ -0,0 +1,173 @@
+package emodl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Reads the next message from a client and checks its opcode.
+func readSevenTVEvent(t *testing.T, conn *websocket.Conn, op int, v any) bool {
+	t.Helper()
+	var msg struct {
+		Op int             `json:"op"`
+		D  json.RawMessage `json:"d"`
+	}
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Error(err)
+		return false
+	}
+	if msg.Op != op {
+		t.Errorf("Expected opcode %d, got %d: %s", op, msg.Op, msg.D)
+		return false
+	}
+	if err := json.Unmarshal(msg.D, v); err != nil {
+		t.Error(err)
+		return false
+	}
+	return true
+}
+
+func writeSevenTVEvent(conn *websocket.Conn, op int, d any) error {
+	return conn.WriteJSON(map[string]any{"op": op, "d": d})
+}
+
+func TestSevenTVEventClient(t *testing.T) {
+	t.Parallel()
+
+	b := fixtureJSON(t, sevenTVBaseURL+"/v3/emote-sets/global")
+	var global struct {
+		Emotes []map[string]any `json:"emotes"`
+	}
+	if err := json.Unmarshal(b, &global); err != nil {
+		t.Fatal(err)
+	}
+	ez := global.Emotes[0]
+
+	wantSubscribe := func(conn *websocket.Conn) bool {
+		var sub sevenTVSubscribe
+		if !readSevenTVEvent(t, conn, sevenTVOpSubscribe, &sub) {
+			return false
+		}
+		if sub.Type != "emote_set.update" || sub.Condition["object_id"] != fakeSevenTVSetID {
+			t.Errorf("Unexpected subscription %+v", sub)
+			return false
+		}
+		return true
+	}
+	dispatch := func(body map[string]any) map[string]any {
+		body["id"] = fakeSevenTVSetID
+		return map[string]any{"type": "emote_set.update", "body": body}
+	}
+
+	events := newFakeSocket(t, func(n int, conn *websocket.Conn) {
+		hello := map[string]any{"heartbeat_interval": 45000, "session_id": fmt.Sprint("session", n)}
+		if writeSevenTVEvent(conn, sevenTVOpHello, hello) != nil {
+			return
+		}
+
+		switch n {
+		case 0:
+			if !wantSubscribe(conn) {
+				return
+			}
+			writeSevenTVEvent(conn, sevenTVOpDispatch, dispatch(map[string]any{
+				"pushed": []any{map[string]any{"key": "emotes", "index": 3, "value": ez}},
+				"pulled": []any{map[string]any{"key": "emotes", "index": 2, "old_value": map[string]any{
+					"id": "6129ca7da4ab6a8c2a20d1f0", "name": "PETPET",
+				}}},
+				"updated": []any{map[string]any{"key": "emotes", "index": 1,
+					"old_value": map[string]any{"id": "60b0c5a0ab9b8d1a5ac14e2f", "name": "peepoHappy"},
+					"value":     map[string]any{"id": "60b0c5a0ab9b8d1a5ac14e2f", "name": "peepoHappier"},
+				}, map[string]any{"key": "name", "old_value": "emodl", "value": "emodl emotes"}},
+			}))
+			writeSevenTVEvent(conn, sevenTVOpReconnect, nil)
+		case 1:
+			var resume sevenTVResume
+			if !readSevenTVEvent(t, conn, sevenTVOpResume, &resume) {
+				return
+			}
+			if resume.SessionID != "session0" {
+				t.Errorf("Resumed session %q, expected session0", resume.SessionID)
+			}
+			writeSevenTVEvent(conn, sevenTVOpAck, map[string]any{
+				"command": "RESUME",
+				"data":    map[string]any{"success": false},
+			})
+			if !wantSubscribe(conn) {
+				return
+			}
+			writeSevenTVEvent(conn, sevenTVOpDispatch, dispatch(map[string]any{
+				"pulled": []any{map[string]any{"key": "emotes", "index": 0, "old_value": map[string]any{
+					"id": "60aea9740e5a4a9a5d5d1c1e", "name": "monkaS",
+				}}},
+			}))
+			// Wait for the client to hang up
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}
+	})
+
+	srv := newFakeServer(t)
+	ed := NewDownloader(fakeChannelOptions(srv))
+	if _, err := ed.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := NewSevenTVEventClient(&ed, LiveOptions{
+		URL:     fakeSocketURL(events),
+		OnError: func(err error) { t.Error(err) },
+	})
+	changes := client.Start(ctx)
+
+	diffs := <-changes
+	if len(diffs) != 1 || diffs[0].Provider != ProviderSevenTV {
+		t.Fatalf("Expected changes to 7TV only, got %+v", diffs)
+	}
+	d := diffs[0]
+	if len(d.Added) != 1 || d.Added[0].Name != "EZ" {
+		t.Errorf("Expected EZ added, got %+v", d.Added)
+	}
+	if len(d.Removed) != 1 || d.Removed[0].Name != "PETPET" {
+		t.Errorf("Expected PETPET removed, got %+v", d.Removed)
+	}
+	if len(d.Renamed) != 1 || d.Renamed[0].OldName != "peepoHappy" || d.Renamed[0].Emote.Name != "peepoHappier" {
+		t.Errorf("Expected peepoHappy renamed, got %+v", d.Renamed)
+	}
+
+	ed.RLock()
+	_, removed := ed.SevenTVEmotes["PETPET"]
+	_, renamed := ed.SevenTVEmotes["peepoHappier"]
+	ed.RUnlock()
+	_, merged := ed.Emotes()["peepoHappier"]
+	if removed || !renamed || !merged {
+		t.Error("Emote maps not updated by event")
+	}
+
+	// Delivered after resuming failed and the sets were subscribed again
+	diffs = <-changes
+	if len(diffs) != 1 || len(diffs[0].Removed) != 1 || diffs[0].Removed[0].Name != "monkaS" {
+		t.Fatalf("Expected monkaS removed, got %+v", diffs)
+	}
+	ed.RLock()
+	_, sevenTV := ed.SevenTVEmotes["monkaS"]
+	ed.RUnlock()
+	e, merged := ed.Emotes()["monkaS"]
+	if sevenTV || !merged || e.ID == "60aea9740e5a4a9a5d5d1c1e" {
+		t.Error("Expected monkaS of another provider in the merged map")
+	}
+
+	cancel()
+	if _, ok := <-changes; ok {
+		t.Fatal("Changes after cancel")
+	}
+}
@@ -1,13 +1,16 @@
 package emodl
 
 import (
+	"context"
 	"log"
+	"strings"
 	"testing"
 )
 
 func TestGet7TVEmoteSet(t *testing.T) {
 	t.Parallel()
-	s, err := get7TVEmoteSet("global")
+	c := fakeAPIClient(t, ProviderSevenTV)
+	s, err := get7TVEmoteSet(context.Background(), c, "global")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -24,20 +27,31 @@ func TestGet7TVEmoteSet(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		t.Logf("\nURL: %s\nID: %s\n", img.URL, img.ID)
+		if img.URL != "https://cdn.7tv.app/emote/60ae3e98b2ecb0150535c6b7/2x.webp" || img.Width != 64 {
+			t.Fatalf("Unexpected image: %v", img)
+		}
 		img, err = e.GetImage("", "")
 		if err != nil {
 			t.Fatal(err)
 		}
-		t.Logf("\nURL: %s\nID: %s\n", img.URL, img.ID)
+		if img.URL != "https://cdn.7tv.app/emote/60ae3e98b2ecb0150535c6b7/1x.avif" {
+			t.Fatalf("Expected first file as fallback, got %v", img)
+		}
+	})
+	t.Run("ServerError", func(t *testing.T) {
+		_, err := get7TVEmoteSet(context.Background(), c, "01BROKEN")
+		if err == nil || !strings.HasPrefix(err.Error(), "503 Service Unavailable\n") {
+			t.Fatalf("Expected status and body in error, got %v", err)
+		}
 	})
 }
 
 func TestGet7TVEmoteSetIDs(t *testing.T) {
 	t.Parallel()
+	c := fakeAPIClient(t, ProviderSevenTV)
 	t.Run("WithPlatformNoID", func(t *testing.T) {
 		t.Parallel()
-		sids, err := get7TVUserEmoteSetIDs("twitch", "")
+		sids, err := get7TVUserEmoteSetIDs(context.Background(), c, "twitch", "")
 		if err == nil {
 			t.Logf("No error with no platform id")
 			t.Fail()
@@ -52,7 +66,7 @@ func TestGet7TVEmoteSetIDs(t *testing.T) {
 	})
 	t.Run("WithPlatformIDNoPlatform", func(t *testing.T) {
 		t.Parallel()
-		sids, err := get7TVUserEmoteSetIDs("", "1048391821")
+		sids, err := get7TVUserEmoteSetIDs(context.Background(), c, "", fakeSevenTVTwitchID)
 		if err == nil {
 			t.Logf("No error with no platform")
 			t.Fail()
@@ -68,15 +82,15 @@ func TestGet7TVEmoteSetIDs(t *testing.T) {
 	})
 	t.Run("WithPlatformAndPID", func(t *testing.T) {
 		t.Parallel()
-		sids, err := get7TVUserEmoteSetIDs("twitch", "1048391821")
+		sids, err := get7TVUserEmoteSetIDs(context.Background(), c, "twitch", fakeSevenTVTwitchID)
 		if err != nil {
 			t.Fatal(err)
 		}
 		if len(sids) != 1 {
 			t.Fatalf("Emote Set IDs: %v has more than one emote set", sids)
 		}
-		if sids[0] != "01JSG36904T5GM79JJXBVTSFKS" {
-			t.Fatalf("Emote Set ID: %s different from expected %s", sids[0], "01JSG36904T5GM79JJXBVTSFKS")
+		if sids[0] != fakeSevenTVSetID {
+			t.Fatalf("Emote Set ID: %s different from expected %s", sids[0], fakeSevenTVSetID)
 		}
 	})
 }
@@ -0,0 +1,316 @@
+package emodl
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	_ "image/png"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Protocols drawing images in a terminal.
+const (
+	// Emotes are written as text
+	TerminalText = iota
+	// https://sw.kovidgoyal.net/kitty/graphics-protocol/
+	TerminalKitty
+	// https://vt100.net/docs/vt3xx-gp/chapter14.html
+	TerminalSixel
+)
+
+// Size of the base64 payload of every kitty graphics command.
+const kittyChunkSize = 4096
+
+type TerminalOptions struct {
+	// TerminalText, TerminalKitty or TerminalSixel
+	Protocol int
+
+	// Scale of the images downloaded ("1x" if empty)
+	Scale string
+
+	// Height of emotes in rows of text with kitty, which scales them (1 if
+	// zero)
+	Rows int
+
+	// Height of emotes in pixels with sixel (the height of the image if
+	// zero)
+	Height int
+}
+
+// RenderTerminal writes tokens, such as those of Compose, to w with their
+// emotes drawn as images with the protocol of opt. Images are downloaded
+// with f in PNG or GIF format. Animated emotes are drawn as their first
+// frame, with their overlays and transforms.
+//
+// Control characters are removed from text so that chat messages cannot
+// send escape sequences to the terminal. Emotes that fail to draw are
+// written as text and their errors are joined.
+func RenderTerminal(ctx context.Context, w io.Writer, f *ImageFetcher, tokens []Token, opt TerminalOptions) error {
+	if opt.Scale == "" {
+		opt.Scale = "1x"
+	}
+	if opt.Rows <= 0 {
+		opt.Rows = 1
+	}
+
+	var buf bytes.Buffer
+	var errs []error
+	for _, tok := range tokens {
+		if tok.Kind != TokenEmote || opt.Protocol == TerminalText {
+			buf.WriteString(terminalText(tok.Text))
+			continue
+		}
+		img, err := terminalImage(ctx, f, tok, opt)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("emodl: %v: failure drawing emote %s", err, tok.Emote.Name))
+			buf.WriteString(terminalText(tok.Text))
+			continue
+		}
+		switch opt.Protocol {
+		case TerminalKitty:
+			writeKitty(&buf, img, opt.Rows)
+		case TerminalSixel:
+			writeSixel(&buf, img)
+		default:
+			return fmt.Errorf("emodl: unknown terminal protocol %d", opt.Protocol)
+		}
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// RenderTerminal writes a chat message to w with the emotes of the last
+// Load drawn as images. See RenderTerminal.
+func (ed *Downloader) RenderTerminal(ctx context.Context, w io.Writer, message string, opt TerminalOptions) error {
+	return RenderTerminal(ctx, w, ed.images, ed.Compose(message), opt)
+}
+
+// Removes the control characters of text but line breaks and tabs.
+func terminalText(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r != '\n' && r != '\t' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// Returns the image of an emote token with its overlays drawn centered on
+// top of it. Images are not premultiplied by alpha, as kitty and sixel
+// expect, so that no precision is lost converting them.
+func terminalImage(ctx context.Context, f *ImageFetcher, tok Token, opt TerminalOptions) (*image.NRGBA, error) {
+	base, err := tokenImage(ctx, f, tok, opt)
+	if err != nil {
+		return nil, err
+	}
+	layers := []*image.NRGBA{base}
+	for _, o := range tok.Overlays {
+		img, err := tokenImage(ctx, f, o, opt)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, img)
+	}
+
+	var size image.Point
+	for _, l := range layers {
+		size.X = max(size.X, l.Bounds().Dx())
+		size.Y = max(size.Y, l.Bounds().Dy())
+	}
+	img := image.NewNRGBA(image.Rectangle{Max: size})
+	for _, l := range layers {
+		offset := size.Sub(l.Bounds().Size()).Div(2)
+		draw.Draw(img, l.Bounds().Add(offset), l, image.Point{}, draw.Over)
+	}
+	return img, nil
+}
+
+// Returns the image of the emote of a token with its transforms applied.
+func tokenImage(ctx context.Context, f *ImageFetcher, tok Token, opt TerminalOptions) (*image.NRGBA, error) {
+	img, err := tok.Emote.Best(opt.Scale, "PNG", "GIF")
+	if err != nil {
+		return nil, err
+	}
+	data, err := f.Fetch(ctx, img)
+	if err != nil {
+		return nil, err
+	}
+	frame, err := decodeFirstFrame(data.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	w, h := frame.Bounds().Dx(), frame.Bounds().Dy()
+	if opt.Protocol == TerminalSixel && opt.Height > 0 && h > 0 {
+		w, h = w*opt.Height/h, opt.Height
+	}
+	if tok.Transform&TransformWide != 0 {
+		w *= 2
+	}
+	return resizeImage(frame, w, h, tok.Transform&TransformFlipX != 0, tok.Transform&TransformFlipY != 0), nil
+}
+
+// Decodes a PNG image or the first frame of a GIF image, drawn on the
+// logical screen of the GIF.
+func decodeFirstFrame(b []byte) (*image.NRGBA, error) {
+	if bytes.HasPrefix(b, []byte("GIF8")) {
+		g, err := gif.DecodeAll(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		if len(g.Image) == 0 {
+			return nil, errors.New("GIF has no frames")
+		}
+		img := image.NewNRGBA(image.Rect(0, 0, g.Config.Width, g.Config.Height))
+		draw.Draw(img, g.Image[0].Bounds(), g.Image[0], g.Image[0].Bounds().Min, draw.Over)
+		return img, nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	img := image.NewNRGBA(image.Rectangle{Max: src.Bounds().Size()})
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+	return img, nil
+}
+
+// Returns a copy of an image of another size, sampling the nearest pixels
+// and mirroring them if asked.
+func resizeImage(src *image.NRGBA, w int, h int, flipX bool, flipY bool) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	for y := range h {
+		sy := y * sh / h
+		if flipY {
+			sy = sh - 1 - sy
+		}
+		for x := range w {
+			sx := x * sw / w
+			if flipX {
+				sx = sw - 1 - sx
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):][:4], src.Pix[src.PixOffset(sx, sy):][:4])
+		}
+	}
+	return dst
+}
+
+// Writes an image as kitty graphics commands transmitting and displaying
+// it at the cursor, scaled to a number of rows. Responses of the terminal
+// are suppressed.
+func writeKitty(buf *bytes.Buffer, img *image.NRGBA, rows int) {
+	payload := base64.StdEncoding.EncodeToString(img.Pix)
+	first := true
+	for {
+		chunk := payload[:min(kittyChunkSize, len(payload))]
+		payload = payload[len(chunk):]
+		more := 0
+		if len(payload) > 0 {
+			more = 1
+		}
+
+		buf.WriteString("\x1b_G")
+		if first {
+			fmt.Fprintf(buf, "a=T,q=2,f=32,s=%d,v=%d,r=%d,", img.Bounds().Dx(), img.Bounds().Dy(), rows)
+			first = false
+		}
+		buf.WriteString("m=" + strconv.Itoa(more) + ";")
+		buf.WriteString(chunk)
+		buf.WriteString("\x1b\\")
+		if more == 0 {
+			return
+		}
+	}
+}
+
+// Writes an image as sixels in the web safe palette. Pixels that are less
+// than half opaque are left transparent.
+func writeSixel(buf *bytes.Buffer, img *image.NRGBA) {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	pal := color.Palette(palette.WebSafe)
+
+	// Palette index of every pixel, or -1 if transparent
+	indices := make([]int, w*h)
+	used := make([]bool, len(pal))
+	for y := range h {
+		for x := range w {
+			c := img.NRGBAAt(x, y)
+			i := -1
+			if c.A >= 0x80 {
+				i = pal.Index(color.RGBA{R: c.R, G: c.G, B: c.B, A: 0xff})
+				used[i] = true
+			}
+			indices[y*w+x] = i
+		}
+	}
+
+	// Transparent background, then the raster attributes and colors
+	fmt.Fprintf(buf, "\x1bP0;1;0q\"1;1;%d;%d", w, h)
+	for i, c := range pal {
+		if used[i] {
+			r, g, b, _ := c.RGBA()
+			fmt.Fprintf(buf, "#%d;2;%d;%d;%d", i, r*100/0xffff, g*100/0xffff, b*100/0xffff)
+		}
+	}
+
+	sixels := make([]byte, w)
+	for top := 0; top < h; top += 6 {
+		if top > 0 {
+			buf.WriteByte('-')
+		}
+		for i := range pal {
+			if !used[i] {
+				continue
+			}
+			drawn := false
+			for x := range w {
+				var bits byte
+				for dy := range min(6, h-top) {
+					if indices[(top+dy)*w+x] == i {
+						bits |= 1 << dy
+					}
+				}
+				sixels[x] = '?' + bits
+				drawn = drawn || bits != 0
+			}
+			if !drawn {
+				continue
+			}
+			buf.WriteString("#" + strconv.Itoa(i))
+			writeSixelRuns(buf, bytes.TrimRight(sixels, "?"))
+			buf.WriteByte('$')
+		}
+	}
+	buf.WriteString("\x1b\\")
+}
+
+// Writes sixels with runs of four or more compressed.
+func writeSixelRuns(buf *bytes.Buffer, sixels []byte) {
+	for len(sixels) > 0 {
+		n := 1
+		for n < len(sixels) && sixels[n] == sixels[0] {
+			n++
+		}
+		if n >= 4 {
+			buf.WriteString("!" + strconv.Itoa(n))
+			buf.WriteByte(sixels[0])
+		} else {
+			buf.Write(sixels[:n])
+		}
+		sixels = sixels[n:]
+	}
+}
@@ -0,0 +1,181 @@
+package emodl
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files of testdata/terminal")
+
+// Returns a PNG image with a red, a blue and a transparent column, and a
+// green last row.
+func testTerminalPNG(t *testing.T, w int, h int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			switch {
+			case y == h-1:
+				img.Set(x, y, color.NRGBA{G: 0xff, A: 0xff})
+			case x%3 == 0:
+				img.Set(x, y, color.NRGBA{R: 0xff, A: 0xff})
+			case x%3 == 1:
+				img.Set(x, y, color.NRGBA{B: 0xff, A: 0xc0})
+			}
+		}
+	}
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+// Returns a 2x1 PNG image with a half transparent orange pixel and an
+// opaque one.
+func testTerminalAlphaPNG(t *testing.T) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.NRGBA{R: 0xff, G: 0x80, A: 0x80})
+	img.Set(1, 0, color.NRGBA{R: 0xff, G: 0x80, A: 0xff})
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+// Returns a 4x4 GIF image whose yellow 2x2 first frame is at its center,
+// followed by a white frame.
+func testTerminalGIF(t *testing.T) []byte {
+	pal := color.Palette{color.Transparent, color.RGBA{0xff, 0xff, 0, 0xff}, color.White}
+	first := image.NewPaletted(image.Rect(1, 1, 3, 3), pal)
+	second := image.NewPaletted(image.Rect(0, 0, 4, 4), pal)
+	for i := range first.Pix {
+		first.Pix[i] = 1
+	}
+	for i := range second.Pix {
+		second.Pix[i] = 2
+	}
+	g := &gif.GIF{
+		Image:  []*image.Paletted{first, second},
+		Delay:  []int{10, 10},
+		Config: image.Config{ColorModel: pal, Width: 4, Height: 4},
+	}
+	var b bytes.Buffer
+	if err := gif.EncodeAll(&b, g); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestRenderTerminal(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{
+		"/small.png": testTerminalPNG(t, 5, 8),
+		"/large.png": testTerminalPNG(t, 40, 40),
+		"/anim.gif":  testTerminalGIF(t),
+		"/alpha.png": testTerminalAlphaPNG(t),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(b)
+	}))
+	defer srv.Close()
+	f := NewImageFetcher(srv.Client(), "", 2)
+
+	image := func(path string, format string) []Image {
+		return []Image{
+			// Terminals cannot draw WEBP images without a decoder
+			{URL: srv.URL + "/missing.webp", ID: path + ".webp", Scale: "1x", Format: "WEBP"},
+			{URL: srv.URL + path, ID: path, Scale: "1x", Format: format},
+		}
+	}
+	m := EmoteMap{
+		"Small":    {Name: "Small", Images: image("/small.png", "PNG")},
+		"Large":    {Name: "Large", Images: image("/large.png", "PNG")},
+		"Alpha":    {Name: "Alpha", Images: image("/alpha.png", "PNG")},
+		"Anim":     {Name: "Anim", Animated: true, Images: image("/anim.gif", "GIF")},
+		"Overlay":  {Name: "Overlay", ZeroWidth: true, Images: image("/anim.gif", "GIF")},
+		"Missing":  {Name: "Missing", Images: []Image{{URL: srv.URL + "/missing.png", ID: "missing", Scale: "1x", Format: "PNG"}}},
+		"NoImages": {Name: "NoImages"},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		message string
+		opt     TerminalOptions
+		err     bool
+	}{
+		{"text", "hi Small\x1b[2J\u009b Anim\tok", TerminalOptions{}, false},
+		{"kitty", "hi Small Anim", TerminalOptions{Protocol: TerminalKitty}, false},
+		{"kitty_chunks", "Large", TerminalOptions{Protocol: TerminalKitty, Rows: 2}, false},
+		{"kitty_alpha", "Alpha", TerminalOptions{Protocol: TerminalKitty}, false},
+		{"kitty_overlay", "Small Overlay", TerminalOptions{Protocol: TerminalKitty}, false},
+		{"sixel", "hi Small Anim", TerminalOptions{Protocol: TerminalSixel}, false},
+		{"sixel_height", "Small", TerminalOptions{Protocol: TerminalSixel, Height: 16}, false},
+		{"sixel_transform", "w! h! Small", TerminalOptions{Protocol: TerminalSixel}, false},
+		{"sixel_alpha", "Alpha", TerminalOptions{Protocol: TerminalSixel}, false},
+		{"sixel_overlay", "Small Overlay", TerminalOptions{Protocol: TerminalSixel}, false},
+		{"fallback", "Missing NoImages\x07 Small", TerminalOptions{Protocol: TerminalSixel}, true},
+	} {
+		var b bytes.Buffer
+		err := RenderTerminal(context.Background(), &b, f, m.Compose(tc.message), tc.opt)
+		if tc.err != (err != nil) {
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		}
+		if bytes.ContainsAny(b.Bytes(), "\a\u009b") {
+			t.Errorf("%s: control characters of the message were written", tc.name)
+		}
+
+		golden := filepath.Join("testdata", "terminal", tc.name+".golden")
+		if *update {
+			if err := os.WriteFile(golden, b.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b.Bytes(), want) {
+			t.Errorf("%s: expected %q\n     got %q", tc.name, want, b.Bytes())
+		}
+	}
+}
+
+func TestDecodeFirstFrame(t *testing.T) {
+	t.Parallel()
+
+	img, err := decodeFirstFrame(testTerminalGIF(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 4, 4) {
+		t.Fatalf("Expected the 4x4 screen of the GIF, got %v", img.Bounds())
+	}
+	if c := img.NRGBAAt(0, 0); c.A != 0 {
+		t.Errorf("Expected a transparent corner, got %v", c)
+	}
+	if c := img.NRGBAAt(1, 2); c != (color.NRGBA{0xff, 0xff, 0, 0xff}) {
+		t.Errorf("Expected the yellow first frame, got %v", c)
+	}
+
+	if _, err := decodeFirstFrame([]byte("RIFF....WEBP")); err == nil {
+		t.Error("Expected an error for WEBP")
+	}
+}
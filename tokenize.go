@@ -0,0 +1,128 @@
+package emodl
+
+import (
+	"strconv"
+	"unicode"
+)
+
+// EmoteMap is a map of emotes indexed by name, such as the map returned by
+// Load.
+type EmoteMap map[string]Emote
+
+// Kinds of a Token.
+const (
+	TokenText = iota
+	TokenEmote
+)
+
+// Token is a part of a chat message, either text or a single emote.
+type Token struct {
+	// TokenText or TokenEmote
+	Kind int
+
+	// Text of the message the token covers
+	Text string
+
+	// Offsets of the token in the message, in bytes and in runes. Starts
+	// are inclusive and ends exclusive.
+	Start     int
+	End       int
+	RuneStart int
+	RuneEnd   int
+
+	// Emote of emote tokens, with the Locations of every occurrence of the
+	// emote in the message
+	Emote Emote
+
+	// Zero-width emotes drawn over the emote in order, and the transforms
+	// applied to it by modifiers. Set by Compose.
+	Overlays  []Token
+	Transform EmoteTransform
+}
+
+// Tokenize splits a chat message into text and emote tokens. Joining the
+// Text of the tokens gives back the message.
+//
+// Like in Twitch chat, emotes are words that are exactly the name of an
+// emote, so "Kappa," is text. Words are separated by Unicode white space
+// and by the U+E0000 tag some clients append to repeated messages.
+//
+// Locations of emotes are "start-end" rune offsets with the end inclusive,
+// the format of the emotes tag of Twitch IRC messages.
+func (m EmoteMap) Tokenize(message string) []Token {
+	var tokens []Token
+	locations := make(map[string][]string)
+
+	// The text token being built, if any
+	text := Token{Kind: TokenText, Start: -1}
+	flush := func(end int, runeEnd int) {
+		if text.Start >= 0 && end > text.Start {
+			text.End, text.RuneEnd = end, runeEnd
+			text.Text = message[text.Start:end]
+			tokens = append(tokens, text)
+		}
+		text = Token{Kind: TokenText, Start: -1}
+	}
+
+	wordStart, wordRuneStart := -1, 0
+	word := func(end int, runeEnd int) {
+		name := message[wordStart:end]
+		e, ok := m[name]
+		if !ok {
+			if text.Start < 0 {
+				text.Start, text.RuneStart = wordStart, wordRuneStart
+			}
+			return
+		}
+		flush(wordStart, wordRuneStart)
+		locations[name] = append(locations[name], strconv.Itoa(wordRuneStart)+"-"+strconv.Itoa(runeEnd-1))
+		tokens = append(tokens, Token{
+			Kind:      TokenEmote,
+			Text:      name,
+			Start:     wordStart,
+			End:       end,
+			RuneStart: wordRuneStart,
+			RuneEnd:   runeEnd,
+			Emote:     e,
+		})
+	}
+
+	runeIndex := 0
+	for i, r := range message {
+		if isWordSeparator(r) {
+			if wordStart >= 0 {
+				word(i, runeIndex)
+				wordStart = -1
+			}
+			if text.Start < 0 {
+				text.Start, text.RuneStart = i, runeIndex
+			}
+		} else if wordStart < 0 {
+			wordStart, wordRuneStart = i, runeIndex
+		}
+		runeIndex++
+	}
+	if wordStart >= 0 {
+		word(len(message), runeIndex)
+	}
+	flush(len(message), runeIndex)
+
+	for i := range tokens {
+		if tokens[i].Kind == TokenEmote {
+			tokens[i].Emote.Locations = locations[tokens[i].Text]
+		}
+	}
+	return tokens
+}
+
+func isWordSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == '\U000E0000'
+}
+
+// Tokenize splits a chat message into text and emote tokens with the
+// emotes of the last Load. See EmoteMap.Tokenize.
+func (ed *Downloader) Tokenize(message string) []Token {
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+	return EmoteMap(ed.emotes).Tokenize(message)
+}
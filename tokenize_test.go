@@ -0,0 +1,101 @@
+package emodl
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+
+	m := EmoteMap{
+		"Kappa":  {ID: "25", Name: "Kappa"},
+		"D:":     {ID: "2", Name: "D:"},
+		"catJAM": {ID: "3", Name: "catJAM"},
+	}
+
+	type tok struct {
+		kind             int
+		text             string
+		start, runeStart int
+	}
+	for _, tc := range []struct {
+		message   string
+		want      []tok
+		locations map[string][]string
+	}{
+		{"Kappa", []tok{{TokenEmote, "Kappa", 0, 0}}, map[string][]string{"Kappa": {"0-4"}}},
+		{"hi there", []tok{{TokenText, "hi there", 0, 0}}, nil},
+		{
+			"hi Kappa  Kappa, D: ",
+			[]tok{
+				{TokenText, "hi ", 0, 0},
+				{TokenEmote, "Kappa", 3, 3},
+				{TokenText, "  Kappa, ", 8, 8},
+				{TokenEmote, "D:", 17, 17},
+				{TokenText, " ", 19, 19},
+			},
+			map[string][]string{"Kappa": {"3-7"}, "D:": {"17-18"}},
+		},
+		{
+			// Rune offsets differ from byte offsets after multibyte runes
+			"héllo catJAM　catJAM \U000E0000",
+			[]tok{
+				{TokenText, "héllo ", 0, 0},
+				{TokenEmote, "catJAM", 7, 6},
+				{TokenText, "　", 13, 12},
+				{TokenEmote, "catJAM", 16, 13},
+				{TokenText, " \U000E0000", 22, 19},
+			},
+			map[string][]string{"catJAM": {"6-11", "13-18"}},
+		},
+		{"catJAM\U000E0000", []tok{{TokenEmote, "catJAM", 0, 0}, {TokenText, "\U000E0000", 6, 6}}, nil},
+		{"", nil, nil},
+	} {
+		tokens := m.Tokenize(tc.message)
+
+		var got []tok
+		var joined strings.Builder
+		for _, token := range tokens {
+			got = append(got, tok{token.Kind, token.Text, token.Start, token.RuneStart})
+			joined.WriteString(token.Text)
+			if tc.message[token.Start:token.End] != token.Text {
+				t.Errorf("%q: byte offsets %d-%d do not cover %q", tc.message, token.Start, token.End, token.Text)
+			}
+			if n := token.RuneEnd - token.RuneStart; n != len([]rune(token.Text)) {
+				t.Errorf("%q: rune offsets %d-%d do not cover %q", tc.message, token.RuneStart, token.RuneEnd, token.Text)
+			}
+			if want, ok := tc.locations[token.Text]; ok && !slices.Equal(token.Emote.Locations, want) {
+				t.Errorf("%q: expected %s locations %v, got %v", tc.message, token.Text, want, token.Emote.Locations)
+			}
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%q: expected %v, got %v", tc.message, tc.want, got)
+		}
+		if joined.String() != tc.message {
+			t.Errorf("%q: tokens join to %q", tc.message, joined.String())
+		}
+	}
+}
+
+func TestDownloaderTokenize(t *testing.T) {
+	t.Parallel()
+	ed := NewDownloader(fakeChannelOptions(newFakeServer(t)))
+	if _, err := ed.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens := ed.Tokenize("monkaS that was close monkaS")
+	if len(tokens) != 3 || tokens[0].Emote.Provider != ProviderFFZ || tokens[2].Kind != TokenEmote {
+		t.Fatalf("Unexpected tokens %+v", tokens)
+	}
+	if want := []string{"0-5", "22-27"}; !slices.Equal(tokens[2].Emote.Locations, want) {
+		t.Errorf("Expected locations %v, got %v", want, tokens[2].Emote.Locations)
+	}
+
+	// Emotes of the map are left untouched
+	if len(ed.Emotes()["monkaS"].Locations) != 0 {
+		t.Error("Tokenize changed the loaded emote")
+	}
+}
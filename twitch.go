@@ -0,0 +1,237 @@
+package emodl
+
+// DOCUMENTATION
+// https://dev.twitch.tv/docs/api/reference/#get-global-emotes
+// https://dev.twitch.tv/docs/api/reference/#get-channel-emotes
+//
+// URL EXAMPLES
+// https://static-cdn.jtvnw.net/emoticons/v2/25/static/dark/3.0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const twitchEmoteTemplate = "https://static-cdn.jtvnw.net/emoticons/v2/{{id}}/{{format}}/{{theme_mode}}/{{scale}}"
+
+// Size of Twitch emotes at scale 1.0.
+const twitchEmoteSize = 28
+
+// Scales of Twitch emote images by Helix scale. Scale 3.0 is four times
+// the size of 1.0.
+var twitchScales = map[string]int{
+	"1.0": 1,
+	"2.0": 2,
+	"3.0": 4,
+}
+
+// Formats of Twitch emote images by Helix format.
+var twitchFormats = map[string]string{
+	"static":   "PNG",
+	"animated": "GIF",
+}
+
+type TwitchOptions struct {
+	// Client ID of the Twitch application
+	ClientID string
+
+	// Returns an app or user access token of the application. Called for
+	// every request, so it should cache the token and refresh it when it
+	// expires.
+	Token func(ctx context.Context) (string, error)
+
+	// ID of the channel to get emotes of (global emotes only if empty)
+	BroadcasterID string
+}
+
+type TwitchEmote struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Images struct {
+		URL1x string `json:"url_1x"`
+		URL2x string `json:"url_2x"`
+		URL4x string `json:"url_4x"`
+	} `json:"images"`
+	// "static" and "animated" if the emote is animated
+	Format []string `json:"format"`
+	// "1.0", "2.0" and "3.0"
+	Scale []string `json:"scale"`
+	// "light" and "dark"
+	ThemeMode []string `json:"theme_mode"`
+
+	// Channel emotes only
+	Tier       string `json:"tier"`
+	EmoteType  string `json:"emote_type"`
+	EmoteSetID string `json:"emote_set_id"`
+
+	// URL template of the response the emote is from
+	Template string `json:"-"`
+}
+
+//easyjson:json
+type TwitchEmoteResponse struct {
+	Data     []TwitchEmote `json:"data"`
+	Template string        `json:"template"`
+}
+
+// URL returns the URL of an image of the emote given a Helix format, theme
+// and scale.
+func (e TwitchEmote) URL(format string, theme string, scale string) string {
+	template := e.Template
+	if template == "" {
+		template = twitchEmoteTemplate
+	}
+	return strings.NewReplacer(
+		"{{id}}", e.ID,
+		"{{format}}", format,
+		"{{theme_mode}}", theme,
+		"{{scale}}", scale,
+	).Replace(template)
+}
+
+// AllImages returns an image of every format, theme and scale of the
+// emote.
+func (e TwitchEmote) AllImages() []Image {
+	imgs := make([]Image, 0, len(e.Format)*len(e.ThemeMode)*len(e.Scale))
+	for _, format := range e.Format {
+		for _, theme := range e.ThemeMode {
+			for _, scale := range e.Scale {
+				n, ok := twitchScales[scale]
+				if !ok {
+					continue
+				}
+				imgs = append(imgs, Image{
+					URL:    e.URL(format, theme, scale),
+					Width:  twitchEmoteSize * n,
+					Height: twitchEmoteSize * n,
+					ID:     e.ID + "/" + format + "/" + theme + "/" + scale,
+					Scale:  strconv.Itoa(n) + "x",
+					Format: twitchFormats[format],
+					Theme:  theme,
+				})
+			}
+		}
+	}
+	return imgs
+}
+
+// GetImage returns the dark theme image of the emote nearest to a scale
+// ("1x", "2x" or "4x") and format ("PNG" or "GIF").
+func (e TwitchEmote) GetImage(scale string, format string) (Image, error) {
+	img, ok := bestImage(e.AllImages(), scale, []string{format, "PNG", "GIF"})
+	if !ok {
+		return Image{}, errors.New("Twitch Emote has no images")
+	}
+	return img, nil
+}
+
+func (e TwitchEmote) AsEmote() (Emote, error) {
+	if e.ID == "" || len(e.Format) == 0 || len(e.ThemeMode) == 0 || len(e.Scale) == 0 {
+		return Emote{}, fmt.Errorf("Twitch emote %q has no images", e.Name)
+	}
+	return Emote{
+		ID:        e.ID,
+		Name:      e.Name,
+		Provider:  ProviderTwitch,
+		Animated:  slices.Contains(e.Format, "animated"),
+		Images:    e.AllImages(),
+		Locations: []string{},
+	}, nil
+}
+
+func getTwitchEmotes(ctx context.Context, c *apiClient, path string) ([]TwitchEmote, error) {
+	var r TwitchEmoteResponse
+	err := c.getJSON(ctx, path, &r)
+	if err != nil {
+		return nil, err
+	}
+	for i := range r.Data {
+		r.Data[i].Template = r.Template
+	}
+	return r.Data, nil
+}
+
+func getTwitchGlobalEmotes(ctx context.Context, c *apiClient) ([]TwitchEmote, error) {
+	return getTwitchEmotes(ctx, c, "/helix/chat/emotes/global")
+}
+
+func getTwitchChannelEmotes(ctx context.Context, c *apiClient, broadcasterID string) ([]TwitchEmote, error) {
+	return getTwitchEmotes(ctx, c, "/helix/chat/emotes?broadcaster_id="+url.QueryEscape(broadcasterID))
+}
+
+type twitchProvider struct {
+	opt    *TwitchOptions
+	client *apiClient
+}
+
+// Twitch is disabled without options since Helix needs credentials.
+func newTwitchProvider(opt DownloaderOptions) Provider {
+	if opt.Twitch == nil {
+		return nil
+	}
+	p := &twitchProvider{
+		opt:    opt.Twitch,
+		client: newAPIClient(opt, ProviderTwitch, twitchBaseURL),
+	}
+	p.client.header = p.header
+	return p
+}
+
+// Adds the credentials to a Helix request.
+func (p *twitchProvider) header(ctx context.Context, h http.Header) error {
+	if p.opt.ClientID == "" || p.opt.Token == nil {
+		return errors.New("Twitch client ID and token are needed")
+	}
+	token, err := p.opt.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("%v: failure getting Twitch token", err)
+	}
+	h.Set("Client-Id", p.opt.ClientID)
+	h.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (p *twitchProvider) Name() string {
+	return ProviderTwitch
+}
+
+func (p *twitchProvider) GlobalEmotes(ctx context.Context) ([]ProviderEmote, error) {
+	es, err := getTwitchGlobalEmotes(ctx, p.client)
+	if err != nil {
+		return nil, err
+	}
+	return twitchProviderEmotes(es), nil
+}
+
+func (p *twitchProvider) ChannelEmotes(ctx context.Context) ([]ProviderEmote, error) {
+	if p.opt.BroadcasterID == "" {
+		return nil, nil
+	}
+	es, err := getTwitchChannelEmotes(ctx, p.client, p.opt.BroadcasterID)
+	if err != nil {
+		return nil, err
+	}
+	return twitchProviderEmotes(es), nil
+}
+
+func (p *twitchProvider) AsEmote(e ProviderEmote) (Emote, error) {
+	te, ok := e.(TwitchEmote)
+	if !ok {
+		return Emote{}, fmt.Errorf("expected TwitchEmote, got %T", e)
+	}
+	return te.AsEmote()
+}
+
+func twitchProviderEmotes(es []TwitchEmote) []ProviderEmote {
+	pes := make([]ProviderEmote, 0, len(es))
+	for _, e := range es {
+		pes = append(pes, e)
+	}
+	return pes
+}
@@ -0,0 +1,382 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package emodl
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson3f687995DecodeGithubComJdavasligilEmodl(in *jlexer.Lexer, out *TwitchEmoteResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+				out.Data = nil
+			} else {
+				in.Delim('[')
+				if out.Data == nil {
+					if !in.IsDelim(']') {
+						out.Data = make([]TwitchEmote, 0, 0)
+					} else {
+						out.Data = []TwitchEmote{}
+					}
+				} else {
+					out.Data = (out.Data)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 TwitchEmote
+					easyjson3f687995DecodeGithubComJdavasligilEmodl1(in, &v1)
+					out.Data = append(out.Data, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "template":
+			out.Template = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3f687995EncodeGithubComJdavasligilEmodl(out *jwriter.Writer, in TwitchEmoteResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix[1:])
+		if in.Data == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Data {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				easyjson3f687995EncodeGithubComJdavasligilEmodl1(out, v3)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"template\":"
+		out.RawString(prefix)
+		out.String(string(in.Template))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TwitchEmoteResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3f687995EncodeGithubComJdavasligilEmodl(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TwitchEmoteResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3f687995EncodeGithubComJdavasligilEmodl(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TwitchEmoteResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3f687995DecodeGithubComJdavasligilEmodl(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TwitchEmoteResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3f687995DecodeGithubComJdavasligilEmodl(l, v)
+}
+func easyjson3f687995DecodeGithubComJdavasligilEmodl1(in *jlexer.Lexer, out *TwitchEmote) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "images":
+			easyjson3f687995Decode(in, &out.Images)
+		case "format":
+			if in.IsNull() {
+				in.Skip()
+				out.Format = nil
+			} else {
+				in.Delim('[')
+				if out.Format == nil {
+					if !in.IsDelim(']') {
+						out.Format = make([]string, 0, 4)
+					} else {
+						out.Format = []string{}
+					}
+				} else {
+					out.Format = (out.Format)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Format = append(out.Format, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "scale":
+			if in.IsNull() {
+				in.Skip()
+				out.Scale = nil
+			} else {
+				in.Delim('[')
+				if out.Scale == nil {
+					if !in.IsDelim(']') {
+						out.Scale = make([]string, 0, 4)
+					} else {
+						out.Scale = []string{}
+					}
+				} else {
+					out.Scale = (out.Scale)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 string
+					v5 = string(in.String())
+					out.Scale = append(out.Scale, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "theme_mode":
+			if in.IsNull() {
+				in.Skip()
+				out.ThemeMode = nil
+			} else {
+				in.Delim('[')
+				if out.ThemeMode == nil {
+					if !in.IsDelim(']') {
+						out.ThemeMode = make([]string, 0, 4)
+					} else {
+						out.ThemeMode = []string{}
+					}
+				} else {
+					out.ThemeMode = (out.ThemeMode)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v6 string
+					v6 = string(in.String())
+					out.ThemeMode = append(out.ThemeMode, v6)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "tier":
+			out.Tier = string(in.String())
+		case "emote_type":
+			out.EmoteType = string(in.String())
+		case "emote_set_id":
+			out.EmoteSetID = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3f687995EncodeGithubComJdavasligilEmodl1(out *jwriter.Writer, in TwitchEmote) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"images\":"
+		out.RawString(prefix)
+		easyjson3f687995Encode(out, in.Images)
+	}
+	{
+		const prefix string = ",\"format\":"
+		out.RawString(prefix)
+		if in.Format == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v7, v8 := range in.Format {
+				if v7 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v8))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"scale\":"
+		out.RawString(prefix)
+		if in.Scale == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v9, v10 := range in.Scale {
+				if v9 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v10))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"theme_mode\":"
+		out.RawString(prefix)
+		if in.ThemeMode == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.ThemeMode {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v12))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"tier\":"
+		out.RawString(prefix)
+		out.String(string(in.Tier))
+	}
+	{
+		const prefix string = ",\"emote_type\":"
+		out.RawString(prefix)
+		out.String(string(in.EmoteType))
+	}
+	{
+		const prefix string = ",\"emote_set_id\":"
+		out.RawString(prefix)
+		out.String(string(in.EmoteSetID))
+	}
+	out.RawByte('}')
+}
+func easyjson3f687995Decode(in *jlexer.Lexer, out *struct {
+	URL1x string `json:"url_1x"`
+	URL2x string `json:"url_2x"`
+	URL4x string `json:"url_4x"`
+}) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url_1x":
+			out.URL1x = string(in.String())
+		case "url_2x":
+			out.URL2x = string(in.String())
+		case "url_4x":
+			out.URL4x = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3f687995Encode(out *jwriter.Writer, in struct {
+	URL1x string `json:"url_1x"`
+	URL2x string `json:"url_2x"`
+	URL4x string `json:"url_4x"`
+}) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url_1x\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.URL1x))
+	}
+	{
+		const prefix string = ",\"url_2x\":"
+		out.RawString(prefix)
+		out.String(string(in.URL2x))
+	}
+	{
+		const prefix string = ",\"url_4x\":"
+		out.RawString(prefix)
+		out.String(string(in.URL4x))
+	}
+	out.RawByte('}')
+}
@@ -0,0 +1,107 @@
+package emodl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTwitchProvider(t *testing.T) {
+	t.Parallel()
+
+	// Helix stand in rejecting requests without credentials
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/twitch/") {
+			if r.Header.Get("Client-Id") != "emodltest" || r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error":"Unauthorized","status":401,"message":"Invalid OAuth token"}`))
+				return
+			}
+			if strings.HasSuffix(r.URL.Path, "/emotes") && r.URL.Query().Get("broadcaster_id") != fakeTwitchID {
+				t.Errorf("Unexpected channel emotes request %s", r.URL)
+			}
+		}
+		serveFixture(w, r)
+	}))
+	defer srv.Close()
+
+	var tokens atomic.Int32
+	opt := fakeOptions(srv)
+	opt.Twitch = &TwitchOptions{
+		ClientID: "emodltest",
+		Token: func(ctx context.Context) (string, error) {
+			tokens.Add(1)
+			return "token", nil
+		},
+		BroadcasterID: fakeTwitchID,
+	}
+	ed := NewDownloader(opt)
+	emotes, err := ed.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ed.TwitchEmotes) != 4 || tokens.Load() != 2 {
+		t.Fatalf("Expected 4 Twitch emotes with 2 tokens, got %d with %d", len(ed.TwitchEmotes), tokens.Load())
+	}
+
+	kappa := emotes["Kappa"]
+	if len(kappa.Images) != 6 {
+		t.Fatalf("Expected 6 images of Kappa, got %+v", kappa.Images)
+	}
+	want := Image{
+		URL:    "https://static-cdn.jtvnw.net/emoticons/v2/25/static/dark/3.0",
+		Width:  112,
+		Height: 112,
+		ID:     "25/static/dark/3.0",
+		Scale:  "4x",
+		Format: "PNG",
+		Theme:  "dark",
+	}
+	if kappa.Images[5] != want {
+		t.Errorf("Expected %+v, got %+v", want, kappa.Images[5])
+	}
+
+	hype := emotes["emodlHype"]
+	if len(hype.Images) != 12 || hype.Images[6].Format != "GIF" {
+		t.Errorf("Expected static and animated images, got %+v", hype.Images)
+	}
+	if !hype.Animated || hype.Scope != ScopeChannel || kappa.Animated || kappa.Scope != ScopeGlobal {
+		t.Errorf("Unexpected Twitch emotes %+v and %+v", hype, kappa)
+	}
+	if ed.TwitchEmotes["emodlHype"].Tier != "1000" {
+		t.Errorf("Unexpected channel emote %+v", ed.TwitchEmotes["emodlHype"])
+	}
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		opt.Twitch = &TwitchOptions{
+			ClientID: "emodltest",
+			Token: func(ctx context.Context) (string, error) {
+				return "expired", nil
+			},
+		}
+		ed := NewDownloader(opt)
+		_, err := ed.Load()
+		if err == nil || !strings.Contains(err.Error(), "Invalid OAuth token") {
+			t.Errorf("Expected Helix error, got %v", err)
+		}
+	})
+
+	t.Run("TokenError", func(t *testing.T) {
+		tokenErr := errors.New("no refresh token")
+		opt.Twitch = &TwitchOptions{
+			ClientID: "emodltest",
+			Token: func(ctx context.Context) (string, error) {
+				return "", tokenErr
+			},
+		}
+		ed := NewDownloader(opt)
+		_, err := ed.Load()
+		if err == nil || !strings.Contains(err.Error(), "no refresh token") {
+			t.Errorf("Expected token error, got %v", err)
+		}
+	})
+}
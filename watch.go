@@ -0,0 +1,186 @@
+package emodl
+
+import (
+	"cmp"
+	"context"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+const defaultWatchInterval = 5 * time.Minute
+
+// Clock is the source of time used by Watch.
+type Clock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type WatchOptions struct {
+	// Time between reloads (5 minutes if zero)
+	Interval time.Duration
+
+	// Maximum random time added to every interval so that many Downloaders
+	// started together do not reload together.
+	Jitter time.Duration
+
+	// Timeout of every reload (downloadTimeout seconds if zero)
+	Timeout time.Duration
+
+	// Called with the changes of every reload that changed anything
+	OnChange func([]EmoteDiff)
+
+	// Called with the error of every reload that failed
+	OnError func(error)
+
+	// Source of time (the system clock if nil)
+	Clock Clock
+}
+
+// EmoteRename is an emote whose ID stayed the same while its name changed.
+type EmoteRename struct {
+	OldName string
+	Emote   Emote
+}
+
+// EmoteDiff holds the changes to the emotes of a provider.
+type EmoteDiff struct {
+	Provider string
+	Added    []Emote
+	Removed  []Emote
+	Renamed  []EmoteRename
+}
+
+// Empty reports whether the diff holds no changes.
+func (d EmoteDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Renamed) == 0
+}
+
+// Watch reloads the emotes of the Downloader every interval until ctx is
+// done and reports what changed to opt.OnChange. Always returns the error
+// of ctx.
+//
+// Changes are relative to the previous load, so call Load first unless the
+// first reload should report every emote as added. Reloads send requests
+// even if the disk cache has fresh responses, and refresh it.
+func (ed *Downloader) Watch(ctx context.Context, opt WatchOptions) error {
+	if opt.Interval <= 0 {
+		opt.Interval = defaultWatchInterval
+	}
+	if opt.Timeout <= 0 {
+		opt.Timeout = downloadTimeout * time.Second
+	}
+	if opt.Clock == nil {
+		opt.Clock = systemClock{}
+	}
+
+	for {
+		wait := opt.Interval
+		if opt.Jitter > 0 {
+			wait += rand.N(opt.Jitter)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-opt.Clock.After(wait):
+		}
+
+		before := ed.snapshot()
+		loadCtx, cancel := context.WithTimeout(revalidate(ctx), opt.Timeout)
+		_, err := ed.LoadContext(loadCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && opt.OnError != nil {
+			opt.OnError(err)
+		}
+
+		diffs := diffSnapshots(ed.providers, before, ed.snapshot())
+		if len(diffs) > 0 && opt.OnChange != nil {
+			opt.OnChange(diffs)
+		}
+	}
+}
+
+// Start runs Watch in a new goroutine and returns a channel receiving the
+// changes of every reload. The channel is closed once ctx is done.
+//
+// opt.OnChange is replaced. Reloads wait for the changes to be received.
+func (ed *Downloader) Start(ctx context.Context, opt WatchOptions) <-chan []EmoteDiff {
+	changes := make(chan []EmoteDiff)
+	opt.OnChange = func(diffs []EmoteDiff) {
+		select {
+		case changes <- diffs:
+		case <-ctx.Done():
+		}
+	}
+	go func() {
+		defer close(changes)
+		ed.Watch(ctx, opt)
+	}()
+	return changes
+}
+
+// Emotes of every provider indexed by scope and ID, so an emote renamed in
+// the channel set of a provider is told apart from the global emote.
+type snapshot map[string]map[sourceEmoteKey]Emote
+
+type sourceEmoteKey struct {
+	channel bool
+	id      string
+}
+
+func (ed *Downloader) snapshot() snapshot {
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+
+	snap := make(snapshot, len(ed.providers))
+	for key, s := range ed.sources {
+		if snap[key.provider] == nil {
+			snap[key.provider] = make(map[sourceEmoteKey]Emote, len(s.emotes))
+		}
+		for _, e := range s.emotes {
+			snap[key.provider][sourceEmoteKey{channel: key.channel, id: e.ID}] = e
+		}
+	}
+	return snap
+}
+
+// Returns the non empty diffs of every provider in provider order. Emotes
+// in every diff are sorted by name.
+func diffSnapshots(providers []Provider, before snapshot, after snapshot) []EmoteDiff {
+	var diffs []EmoteDiff
+	for _, p := range providers {
+		d := EmoteDiff{Provider: p.Name()}
+		old, cur := before[p.Name()], after[p.Name()]
+		for key, e := range cur {
+			prev, ok := old[key]
+			if !ok {
+				d.Added = append(d.Added, e)
+			} else if prev.Name != e.Name {
+				d.Renamed = append(d.Renamed, EmoteRename{OldName: prev.Name, Emote: e})
+			}
+		}
+		for key, e := range old {
+			if _, ok := cur[key]; !ok {
+				d.Removed = append(d.Removed, e)
+			}
+		}
+		if d.Empty() {
+			continue
+		}
+
+		byName := func(a, b Emote) int { return cmp.Compare(a.Name, b.Name) }
+		slices.SortFunc(d.Added, byName)
+		slices.SortFunc(d.Removed, byName)
+		slices.SortFunc(d.Renamed, func(a, b EmoteRename) int { return byName(a.Emote, b.Emote) })
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
@@ -0,0 +1,166 @@
+package emodl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Clock whose timers fire when the test says so.
+type manualClock struct {
+	waits chan time.Duration
+	ticks chan time.Time
+}
+
+func newManualClock() *manualClock {
+	return &manualClock{
+		waits: make(chan time.Duration, 1),
+		ticks: make(chan time.Time),
+	}
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.waits <- d
+	return c.ticks
+}
+
+// Returns a fake server whose 7TV channel set changes once updated is set.
+func newWatchServer(t *testing.T) (*httptest.Server, *atomic.Bool) {
+	t.Helper()
+	setPath := "/7tv/v3/emote-sets/" + fakeSevenTVSetID
+	b := fixtureJSON(t, sevenTVBaseURL+"/v3/emote-sets/"+fakeSevenTVSetID)
+	var set map[string]any
+	if err := json.Unmarshal(b, &set); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rename peepoHappy, remove PETPET and add EZ from the global set
+	emotes := set["emotes"].([]any)
+	emotes[1].(map[string]any)["name"] = "peepoHappier"
+	global := fixtureJSON(t, sevenTVBaseURL+"/v3/emote-sets/global")
+	var globalSet map[string]any
+	if err := json.Unmarshal(global, &globalSet); err != nil {
+		t.Fatal(err)
+	}
+	set["emotes"] = []any{emotes[0], emotes[1], globalSet["emotes"].([]any)[0]}
+	changed, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated := new(atomic.Bool)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if updated.Load() && r.URL.Path == setPath {
+			w.Write(changed)
+			return
+		}
+		serveFixture(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, updated
+}
+
+func TestDownloaderWatch(t *testing.T) {
+	t.Parallel()
+	srv, updated := newWatchServer(t)
+
+	ed := NewDownloader(fakeChannelOptions(srv))
+	if _, err := ed.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := newManualClock()
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := ed.Start(ctx, WatchOptions{
+		Interval: time.Minute,
+		Jitter:   time.Second,
+		Clock:    clock,
+		OnError:  func(err error) { t.Error(err) },
+	})
+
+	if d := <-clock.waits; d < time.Minute || d >= time.Minute+time.Second {
+		t.Fatalf("Wait of %v outside interval and jitter", d)
+	}
+	updated.Store(true)
+	clock.ticks <- time.Now()
+
+	diffs := <-changes
+	if len(diffs) != 1 || diffs[0].Provider != ProviderSevenTV {
+		t.Fatalf("Expected changes to 7TV only, got %+v", diffs)
+	}
+	d := diffs[0]
+	if len(d.Added) != 1 || d.Added[0].Name != "EZ" {
+		t.Errorf("Expected EZ added, got %+v", d.Added)
+	}
+	if len(d.Removed) != 1 || d.Removed[0].Name != "PETPET" {
+		t.Errorf("Expected PETPET removed, got %+v", d.Removed)
+	}
+	if len(d.Renamed) != 1 || d.Renamed[0].OldName != "peepoHappy" || d.Renamed[0].Emote.Name != "peepoHappier" {
+		t.Errorf("Expected peepoHappy renamed, got %+v", d.Renamed)
+	}
+
+	ed.RLock()
+	_, removed := ed.SevenTVEmotes["PETPET"]
+	ed.RUnlock()
+	_, renamed := ed.Emotes()["peepoHappier"]
+	if removed || !renamed {
+		t.Error("Emote maps not updated by reload")
+	}
+
+	// Nothing changes on the next reload
+	<-clock.waits
+	clock.ticks <- time.Now()
+	<-clock.waits
+	cancel()
+	if _, ok := <-changes; ok {
+		t.Fatal("Changes reported for an unchanged reload")
+	}
+}
+
+func TestDownloaderWatchCache(t *testing.T) {
+	t.Parallel()
+	srv, updated := newWatchServer(t)
+
+	opt := fakeChannelOptions(srv)
+	opt.Cache = newTestDiskCache(t, DiskCacheOptions{Dir: t.TempDir()}, nil)
+	ed := NewDownloader(opt)
+	if _, err := ed.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := newManualClock()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := ed.Start(ctx, WatchOptions{
+		Interval: time.Minute,
+		Clock:    clock,
+		OnError:  func(err error) { t.Error(err) },
+	})
+
+	// The reload sees the change although the cached set is still fresh
+	<-clock.waits
+	updated.Store(true)
+	clock.ticks <- time.Now()
+	var diffs []EmoteDiff
+	select {
+	case diffs = <-changes:
+	case <-clock.waits:
+		t.Fatal("Reload served from the cache")
+	}
+	if len(diffs) != 1 || diffs[0].Provider != ProviderSevenTV || len(diffs[0].Added) != 1 {
+		t.Fatalf("Expected EZ added to 7TV, got %+v", diffs)
+	}
+
+	// The cache holds the reloaded set
+	ed = NewDownloader(opt)
+	if _, err := ed.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ed.Emotes()["peepoHappier"]; !ok {
+		t.Error("Reloaded set not cached")
+	}
+}